@@ -0,0 +1,442 @@
+//Command cronlint checks the schedules in crontab files and in YAML, JSON, or TOML job
+//definitions.
+//
+//Usage:
+//
+//	cronlint [flags] [file ...]
+//
+//For each schedule, cronlint prints its English description and its next fire times, or
+//every problem found in it. A schedule that never fires after -from is invalid. It reads
+//standard input when no files are given or a file is "-". It exits with status 1 if any
+//schedule is invalid, or has warnings with -strict, and with status 2 if a file cannot be
+//read.
+//
+//Files ending in .yaml, .yml, .json, or .toml are job definitions and other files are
+//crontabs, unless -format is given. Job definitions are the jobs files of packages reload
+//and jobfile: a list of cron.JobSpecs, or an object or table with a "jobs" list of them:
+//
+//	jobs:
+//	  - id: backup
+//	    schedule: "0 0 2 * * *"
+//	    timezone: America/New_York
+//	    jitter: 5m
+//
+//The schedule of each job is checked with its id as the seed, and its next fire times are
+//in its timezone and delayed by its jitter, as when the job is applied to a cron.Cron.
+//
+//Each crontab line is a schedule followed by a command, as package crontab parses them. The
+//schedule is a directive, such as "@daily" or "@every 1h", or the number of fields given by
+//-fields. Blank lines, comments, and environment assignments are skipped, except that
+//CRON_TZ sets the time zone of the next fire times of the lines after it.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gogolfing/cron"
+	"github.com/gogolfing/cron/crontab"
+	"github.com/gogolfing/cron/jobfile"
+	"github.com/gogolfing/cron/reload"
+	"github.com/gogolfing/cron/sched"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatAuto    = "auto"
+	formatCrontab = "crontab"
+	formatJobs    = "jobs"
+
+	exitInvalid = 1
+	exitError   = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+//options are the flags of a run.
+type options struct {
+	format string
+	fields int
+	count  int
+	from   time.Time
+	loc    *time.Location
+	strict bool
+	quiet  bool
+
+	//random seeds the Source of each schedule's parser, so that its Random values are the
+	//same when it is linted and when it is parsed.
+	random int64
+}
+
+//entry is a single schedule expression read from a file.
+type entry struct {
+	name       string
+	line       int
+	expression string
+
+	//loc is the time zone of the next fire times of the entry.
+	loc *time.Location
+
+	//spec is the job of the entry, or nil if it is from a crontab.
+	spec *cron.JobSpec
+
+	//err is a problem with the entry other than its expression.
+	err error
+}
+
+//run runs cronlint with args and returns its exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("cronlint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	o := &options{random: time.Now().UnixNano()}
+	fs.StringVar(&o.format, "format", formatAuto, `file format: "auto", "crontab", or "jobs" for YAML, JSON, or TOML`)
+	fs.IntVar(&o.fields, "fields", 5, "number of schedule fields on each crontab line that is not a directive")
+	fs.IntVar(&o.count, "n", 5, "number of next fire times to print for each schedule")
+	from := fs.String("from", "", "RFC 3339 time to find the next fire times after (default now)")
+	tz := fs.String("tz", "Local", "time zone of the next fire times")
+	fs.BoolVar(&o.strict, "strict", false, "exit with status 1 if there are warnings")
+	fs.BoolVar(&o.quiet, "q", false, "only print problems")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if o.format != formatAuto && o.format != formatCrontab && o.format != formatJobs {
+		fmt.Fprintf(stderr, "cronlint: unknown format %q\n", o.format)
+		return exitError
+	}
+	var err error
+	if o.loc, err = time.LoadLocation(*tz); err != nil {
+		fmt.Fprintf(stderr, "cronlint: %v\n", err)
+		return exitError
+	}
+	o.from = time.Now()
+	if *from != "" {
+		if o.from, err = time.Parse(time.RFC3339, *from); err != nil {
+			fmt.Fprintf(stderr, "cronlint: -from: %v\n", err)
+			return exitError
+		}
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	s := &summary{}
+	for _, file := range files {
+		entries, err := readFile(file, stdin, o)
+		if err != nil {
+			fmt.Fprintf(stderr, "cronlint: %v\n", err)
+			return exitError
+		}
+		for _, e := range entries {
+			lintEntry(stdout, file, e, o, s)
+		}
+	}
+	fmt.Fprintf(stderr, "cronlint: %v\n", s)
+	if s.errors > 0 || (o.strict && s.warnings > 0) {
+		return exitInvalid
+	}
+	return 0
+}
+
+//summary counts the results of a run.
+type summary struct {
+	schedules int
+	errors    int
+	warnings  int
+}
+
+func (s *summary) String() string {
+	return fmt.Sprintf("%v, %v, %v", plural(s.schedules, "schedule"), plural(s.errors, "error"), plural(s.warnings, "warning"))
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%v %v", n, noun)
+	}
+	return fmt.Sprintf("%v %vs", n, noun)
+}
+
+//lintEntry prints the description and next fire times of e, or its problems, to w.
+func lintEntry(w io.Writer, file string, e *entry, o *options, s *summary) {
+	s.schedules++
+	header := fmt.Sprintf("%v:%v:", displayName(file), e.line)
+	if e.name != "" {
+		header += " " + e.name + ":"
+	}
+	if e.err != nil {
+		s.errors++
+		fmt.Fprintf(w, "%v %v\n", header, e.err)
+		return
+	}
+
+	schedule, warnings, err := parseEntry(e, o)
+	if err != nil {
+		var errs sched.ParseErrors
+		var pe *sched.ParseError
+		switch {
+		case errors.As(err, &errs):
+		case errors.As(err, &pe):
+			errs = sched.ParseErrors{pe}
+		default:
+			s.errors++
+			fmt.Fprintf(w, "%v %v\n", header, err)
+			return
+		}
+		s.errors += len(errs)
+		fmt.Fprintf(w, "%v %v\n", header, e.expression)
+		for _, pe := range errs {
+			fmt.Fprintln(w, indent(pe.Pretty()))
+		}
+		return
+	}
+	from := o.from.In(e.loc)
+	if _, ok := schedule.NextTime(from); !ok && !sched.IsReboot(schedule) {
+		s.errors++
+		fmt.Fprintf(w, "%v %v\n", header, e.expression)
+		fmt.Fprintln(w, indent(fmt.Sprintf("never fires after %v", from.Format(time.RFC3339))))
+		return
+	}
+	s.warnings += len(warnings)
+	if o.quiet && len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%v %v\n", header, e.expression)
+	for _, warning := range warnings {
+		fmt.Fprintln(w, indent(warning.Pretty()))
+	}
+	if o.quiet {
+		return
+	}
+	fmt.Fprintln(w, indent(sched.Describe(schedule)))
+	for _, t := range nextTimes(schedule, from, o.count) {
+		fmt.Fprintln(w, indent(t.Format(time.RFC3339)))
+	}
+}
+
+//parseEntry returns the schedule of e and the warnings about its expression.
+//The expression is linted and parsed by parsers with the same Source, so that the warnings
+//are about the Random values of the schedule.
+func parseEntry(e *entry, o *options) (sched.Schedule, []*sched.Warning, error) {
+	p := &sched.Parser{Source: rand.NewSource(o.random)}
+	if e.spec != nil {
+		p.Seed = e.spec.ID
+	}
+	warnings, err := p.Lint(e.expression)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.Source = rand.NewSource(o.random)
+	schedule, err := p.Parse(e.expression)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.spec != nil {
+		if schedule, err = e.spec.Wrap(schedule); err != nil {
+			return nil, nil, err
+		}
+	}
+	return schedule, warnings, nil
+}
+
+//nextTimes returns up to count of the next times of s after from.
+func nextTimes(s sched.Schedule, from time.Time, count int) []time.Time {
+	result := []time.Time{}
+	for len(result) < count {
+		next, ok := s.NextTime(from)
+		if !ok {
+			break
+		}
+		result = append(result, next)
+		from = next
+	}
+	return result
+}
+
+func indent(text string) string {
+	return "    " + strings.Replace(text, "\n", "\n    ", -1)
+}
+
+func displayName(file string) string {
+	if file == "-" {
+		return "<stdin>"
+	}
+	return file
+}
+
+//readFile returns the entries of file, which is read from stdin if it is "-".
+func readFile(file string, stdin io.Reader, o *options) ([]*entry, error) {
+	var r io.Reader = stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	format := o.format
+	if format == formatAuto {
+		format = formatCrontab
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml", ".json", ".toml":
+			format = formatJobs
+		}
+	}
+	if format == formatJobs {
+		entries, err := readJobs(r, file, o)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", displayName(file), err)
+		}
+		return entries, nil
+	}
+	entries, err := readCrontab(r, o)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", displayName(file), err)
+	}
+	return entries, nil
+}
+
+//readCrontab returns an entry for each schedule line of r.
+func readCrontab(r io.Reader, o *options) ([]*entry, error) {
+	result := []*entry{}
+	p := &crontab.Parser{Fields: o.fields}
+	scanner := crontab.NewScanner(r)
+	for scanner.Scan() {
+		if err := scanner.LineErr(); err != nil {
+			result = append(result, &entry{line: scanner.Line(), err: err})
+			continue
+		}
+		text := scanner.Text()
+		expression, rest, err := p.SplitEntry(text)
+		e := &entry{line: scanner.Line(), expression: expression, loc: o.loc}
+		if loc := scanner.Location(); loc != nil {
+			e.loc = loc
+		}
+		if err == nil && rest == "" {
+			err = fmt.Errorf("command is missing")
+		}
+		if err != nil {
+			e.err = fmt.Errorf("%q: %v", text, err)
+		}
+		result = append(result, e)
+	}
+	return result, scanner.Err()
+}
+
+//readJobs returns an entry for each job defined in r, which is decoded as package jobfile
+//decodes file. Files without a known extension, such as standard input, are YAML.
+func readJobs(r io.Reader, file string, o *options) ([]*entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	decode, findLines := jobfile.DecodeYAML, jobLines
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		decode = reload.DecodeJSON
+	case ".toml":
+		decode, findLines = jobfile.DecodeTOML, tomlJobLines
+	}
+	specs, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	lines := findLines(data)
+	result := make([]*entry, 0, len(specs))
+	ids := map[string]bool{}
+	for i, spec := range specs {
+		e := &entry{name: spec.ID, expression: spec.Schedule, loc: o.loc, spec: spec}
+		if i < len(lines) {
+			e.line = lines[i]
+		}
+		switch {
+		case spec.ID == "":
+			e.err = fmt.Errorf("job %v does not have an id", i+1)
+		case ids[spec.ID]:
+			e.err = fmt.Errorf("job %q is declared more than once", spec.ID)
+		case spec.Schedule == "":
+			e.err = fmt.Errorf("job %q does not have a schedule", spec.ID)
+		default:
+			//problems with the schedule itself are found when the entry is linted.
+			var pe *sched.ParseError
+			if _, err := spec.NewSchedule(); err != nil && !errors.As(err, &pe) {
+				e.err = err
+			}
+		}
+		ids[spec.ID] = true
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+//jobLines returns the line of the schedule of each job in data, or of the job if it does not
+//have one. data has already been decoded, so it is valid YAML, which JSON also is.
+func jobLines(data []byte) []int {
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(data, root); err != nil {
+		return nil
+	}
+	list := root
+	if list.Kind == yaml.DocumentNode && len(list.Content) > 0 {
+		list = list.Content[0]
+	}
+	if list.Kind == yaml.MappingNode {
+		list = mappingValue(list, "jobs")
+	}
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+	result := make([]int, 0, len(list.Content))
+	for _, node := range list.Content {
+		line := node.Line
+		if value := mappingValue(node, "schedule"); value != nil {
+			line = value.Line
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+//tomlJobLines returns the line of the schedule of each job in data, which is a TOML "jobs"
+//array of tables, or of the job's table header if it does not have one.
+func tomlJobLines(data []byte) []int {
+	result := []int{}
+	inJob := false
+	for i, text := range strings.Split(string(data), "\n") {
+		text = strings.TrimSpace(text)
+		switch {
+		case text == "[[jobs]]":
+			result = append(result, i+1)
+			inJob = true
+		case strings.HasPrefix(text, "["):
+			//a table of a job, such as its data, or another table.
+			inJob = false
+		case inJob && strings.HasPrefix(text, "schedule") && strings.HasPrefix(strings.TrimSpace(text[len("schedule"):]), "="):
+			result[len(result)-1] = i + 1
+		}
+	}
+	return result
+}
+
+//mappingValue returns the value of key in node, or nil if node is not a mapping or does not
+//have key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testFrom = "2026-10-19T08:00:00Z"
+
+func runTest(t *testing.T, stdin string, args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	args = append([]string{"-from", testFrom, "-tz", "UTC"}, args...)
+	status := run(args, strings.NewReader(stdin), stdout, stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+func TestRun_crontab(t *testing.T) {
+	crontab := strings.Join([]string{
+		"# nightly jobs",
+		"SHELL=/bin/sh",
+		"",
+		"30 2 * * 1-5 /usr/bin/backup --full",
+		"@hourly rotate-logs",
+		"@every 15m from 00:05 poll",
+	}, "\n")
+	status, stdout, stderr := runTest(t, crontab, "-n", "2")
+	want := strings.Join([]string{
+		"<stdin>:4: 30 2 * * 1-5",
+		"    At 02:30 on every weekday",
+		"    2026-10-20T02:30:00Z",
+		"    2026-10-21T02:30:00Z",
+		"<stdin>:5: @hourly",
+		"    Every hour",
+		"    2026-10-19T09:00:00Z",
+		"    2026-10-19T10:00:00Z",
+		"<stdin>:6: @every 15m from 00:05",
+		"    Every 15 minutes offset by 5 minutes",
+		"    2026-10-19T08:05:00Z",
+		"    2026-10-19T08:20:00Z",
+		"",
+	}, "\n")
+	if status != 0 || stdout != want {
+		t.Errorf("run() = %v, %q WANT 0, %q", status, stdout, want)
+	}
+	if wantSummary := "cronlint: 3 schedules, 0 errors, 0 warnings\n"; stderr != wantSummary {
+		t.Errorf("run() stderr = %q WANT %q", stderr, wantSummary)
+	}
+}
+
+func TestRun_crontabTimeZone(t *testing.T) {
+	_, stdout, _ := runTest(t, "CRON_TZ=America/New_York\n0 9 * * * report\n", "-n", "1")
+	if !strings.Contains(stdout, "2026-10-19T09:00:00-04:00") {
+		t.Errorf("run() stdout = %q WANT a time in America/New_York", stdout)
+	}
+	status, stdout, _ := runTest(t, "CRON_TZ=Nowhere/Nothing\n0 9 * * * report\n", "-n", "1")
+	want := "<stdin>:1: CRON_TZ: unknown time zone Nowhere/Nothing\n<stdin>:2: 0 9 * * *\n"
+	if status != exitInvalid || !strings.HasPrefix(stdout, want) {
+		t.Errorf("run() = %v, %q WANT %v, %q", status, stdout, exitInvalid, want)
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	crontab := "70 25 * * * cmd\n0 0 * * *\n"
+	status, stdout, stderr := runTest(t, crontab)
+	if status != exitInvalid {
+		t.Errorf("run() status = %v WANT %v", status, exitInvalid)
+	}
+	for _, want := range []string{
+		"<stdin>:1: 70 25 * * *\n",
+		"minute field: not in range\n      70 25 * * *\n      ^^\n",
+		"hour field: not in range\n      70 25 * * *\n         ^^\n",
+		`<stdin>:2: "0 0 * * *": command is missing`,
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("run() stdout = %q WANT it to contain %q", stdout, want)
+		}
+	}
+	if wantSummary := "cronlint: 2 schedules, 3 errors, 0 warnings\n"; stderr != wantSummary {
+		t.Errorf("run() stderr = %q WANT %q", stderr, wantSummary)
+	}
+}
+
+func TestRun_neverFires(t *testing.T) {
+	crontab := "0 0 0 1 1 * 2020 cmd\n@reboot cmd\n"
+	status, stdout, stderr := runTest(t, crontab, "-fields", "7")
+	if status != exitInvalid {
+		t.Errorf("run() status = %v WANT %v", status, exitInvalid)
+	}
+	want := "<stdin>:1: 0 0 0 1 1 * 2020\n    never fires after 2026-10-19T08:00:00Z\n<stdin>:2: @reboot\n"
+	if !strings.HasPrefix(stdout, want) {
+		t.Errorf("run() stdout = %q WANT it to start with %q", stdout, want)
+	}
+	if wantSummary := "cronlint: 2 schedules, 1 error, 0 warnings\n"; stderr != wantSummary {
+		t.Errorf("run() stderr = %q WANT %q", stderr, wantSummary)
+	}
+}
+
+func TestRun_warnings(t *testing.T) {
+	crontab := "*/7 * * * * cmd\n"
+	if status, stdout, _ := runTest(t, crontab, "-q"); status != 0 || !strings.Contains(stdout, "step value 7") {
+		t.Errorf("run(-q) = %v, %q WANT 0 and a warning", status, stdout)
+	}
+	if status, _, _ := runTest(t, crontab, "-strict"); status != exitInvalid {
+		t.Errorf("run(-strict) status = %v WANT %v", status, exitInvalid)
+	}
+	if _, stdout, _ := runTest(t, "0 * * * * cmd\n", "-q"); stdout != "" {
+		t.Errorf("run(-q) stdout = %q WANT empty", stdout)
+	}
+}
+
+func TestRun_jobs(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "jobs.yaml")
+	jsonFile := filepath.Join(dir, "jobs.json")
+	yamlJobs := strings.Join([]string{
+		"jobs:",
+		"  - id: backup",
+		"    schedule: \"0 0 2 * * *\"",
+		"    timezone: America/New_York",
+		"  - id: broken",
+		"    schedule: \"0 0 2 * * * * *\"",
+		"  - id: missing",
+		"  - schedule: \"@daily\"",
+		"  - id: backup",
+		"    schedule: \"@daily\"",
+		"  - id: nowhere",
+		"    schedule: \"@daily\"",
+		"    timezone: Nowhere/Nothing",
+		"",
+	}, "\n")
+	jsonJobs := "[\n  {\"id\": \"report\", \"schedule\": \"@daily\", \"jitter\": \"1m\"}\n]\n"
+	if err := os.WriteFile(yamlFile, []byte(yamlJobs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonFile, []byte(jsonJobs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	status, stdout, _ := runTest(t, "", "-n", "1", yamlFile, jsonFile)
+	if status != exitInvalid {
+		t.Errorf("run() status = %v WANT %v", status, exitInvalid)
+	}
+	for _, want := range []string{
+		yamlFile + ":3: backup: 0 0 2 * * *\n    At 02:00 every day in America/New_York time\n    2026-10-20T02:00:00-04:00\n",
+		yamlFile + ":6: broken: 0 0 2 * * * * *\n    sched: could not parse",
+		yamlFile + ":7: missing: job \"missing\" does not have a schedule\n",
+		yamlFile + ":8: job 4 does not have an id\n",
+		yamlFile + ":10: backup: job \"backup\" is declared more than once\n",
+		yamlFile + ":12: nowhere: job \"nowhere\":",
+		jsonFile + ":2: report: @daily\n    At 00:00 every day delayed by up to 1 minute\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("run() stdout = %q WANT it to contain %q", stdout, want)
+		}
+	}
+	if strings.Contains(stdout, "2026-10-20T00:00:00Z") {
+		t.Errorf("run() stdout = %q WANT report to fire after midnight with its jitter", stdout)
+	}
+}
+
+func TestParseEntry_random(t *testing.T) {
+	from := time.Date(2026, time.October, 19, 8, 0, 0, 0, time.UTC)
+	for random := int64(0); random < 50; random++ {
+		e := &entry{expression: "0 0 R * *", loc: time.UTC}
+		schedule, warnings, err := parseEntry(e, &options{random: random})
+		if err != nil {
+			t.Fatalf("parseEntry(%v) error = %v", random, err)
+		}
+		next, _ := schedule.NextTime(from)
+		//the warning about a day that is not in every month is about the day that R resolves to.
+		want := 0
+		if next.Day() > 28 {
+			want = 1
+		}
+		if len(warnings) != want {
+			t.Fatalf("parseEntry(%v) = %v, %v WANT %v warnings", random, next, warnings, want)
+		}
+		if want == 1 && !strings.Contains(warnings[0].String(), fmt.Sprintf("day %v ", next.Day())) {
+			t.Errorf("parseEntry(%v) = %v, %v WANT a warning about day %v", random, next, warnings[0], next.Day())
+		}
+	}
+}
+
+func TestRun_jobsTOML(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "jobs.toml")
+	jobs := strings.Join([]string{
+		"[[jobs]]",
+		"id = \"backup\"",
+		"schedule = \"0 0 2 * * *\"",
+		"[jobs.data]",
+		"schedule = \"not a schedule\"",
+		"",
+		"[[jobs]]",
+		"id = \"broken\"",
+		"schedule = \"0 70 * * * *\"",
+		"",
+	}, "\n")
+	if err := os.WriteFile(file, []byte(jobs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	status, stdout, _ := runTest(t, "", "-n", "1", file)
+	if status != exitInvalid {
+		t.Errorf("run() status = %v WANT %v", status, exitInvalid)
+	}
+	for _, want := range []string{
+		file + ":3: backup: 0 0 2 * * *\n    At 02:00 every day\n    2026-10-20T02:00:00Z\n",
+		file + ":9: broken: 0 70 * * * *\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("run() stdout = %q WANT it to contain %q", stdout, want)
+		}
+	}
+}
+
+func TestRun_badInput(t *testing.T) {
+	tests := [][]string{
+		{"-format", "xml"},
+		{"-tz", "Nowhere/Nothing"},
+		{filepath.Join(t.TempDir(), "missing")},
+		{"-format", "jobs", "-"},
+	}
+	for _, args := range tests {
+		if status, _, _ := runTest(t, "name: value\n", args...); status != exitError {
+			t.Errorf("run(%v) status = %v WANT %v", args, status, exitError)
+		}
+	}
+}
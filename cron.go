@@ -11,6 +11,13 @@ import (
 type Job struct {
 	sched.Schedule
 	Data interface{}
+
+	//ID identifies the job to code that manages the jobs of a Cron, such as a reloader of
+	//job definitions. It may be empty.
+	ID string
+
+	//Spec is the JobSpec that the job was applied from, or nil if it was added another way.
+	Spec *JobSpec
 }
 
 type Event struct {
@@ -18,69 +25,338 @@ type Event struct {
 	Time time.Time
 }
 
+//queue is the part of a timequeue.TimeQueue that a Cron uses, so that tests can release
+//messages without waiting for their times.
+type queue interface {
+	Push(t time.Time, data interface{}) *timequeue.Message
+	Remove(message *timequeue.Message) bool
+	Messages() <-chan *timequeue.Message
+	Start()
+	Stop()
+}
+
 type Cron struct {
 	lock     *sync.Mutex
 	jobs     map[*Job]bool
 	events   chan *Event
 	location *time.Location
+
+	//now returns the current time, and is time.Now outside of tests.
+	now func() time.Time
+
+	queue    queue
+	messages map[*Job]*timequeue.Message
+	stop     chan struct{}
+	running  bool
+
+	//done is closed when the goroutine that releases Events for the current run returns.
+	done chan struct{}
+
+	//started is whether the Cron has ever been started, and has released its
+	//sched.IsReboot jobs.
+	started bool
+
+	//stopped is when the Cron was last stopped, which is when MisfireRunOnce jobs missed
+	//times from.
+	stopped time.Time
+
+	//calendar excludes times from the schedules of every job if not nil.
+	calendar sched.Calendar
 }
 
 func NewCron(loc *time.Location) *Cron {
+	if loc == nil {
+		loc = time.Local
+	}
 	return &Cron{
-		lock:   &sync.Mutex{},
-		jobs:   map[*Job]bool{},
-		events: make(chan *Event),
+		lock:     &sync.Mutex{},
+		jobs:     map[*Job]bool{},
+		events:   make(chan *Event),
+		location: loc,
+		now:      time.Now,
+		queue:    timequeue.New(),
+		messages: map[*Job]*timequeue.Message{},
 	}
 }
 
 func (c *Cron) Add(schedStr string, data interface{}) (*Job, error) {
-	return nil, nil
+	s, err := sched.Parse(schedStr)
+	if err != nil {
+		return nil, err
+	}
+	return c.AddSchedule(s, data), nil
 }
 
 func (c *Cron) AddSchedule(sched sched.Schedule, data interface{}) *Job {
-	return nil
+	job := newJob(sched, data)
+	c.AddJob(job)
+	return job
 }
 
 func (c *Cron) AddJob(job *Job) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.addJob(job, c.now())
 }
 
+//addJob adds job to c. c.lock must be held.
+func (c *Cron) addJob(job *Job, now time.Time) {
+	c.jobs[job] = true
+	if c.running {
+		c.pushJob(job, now)
+	}
+}
+
+//Remove removes job from c and returns whether it was in c.
+//If emit is true and job was waiting to be released, its Event is released immediately
+//instead of being discarded, and Remove blocks until it is received.
 func (c *Cron) Remove(job *Job, emit bool) bool {
-	return false
+	c.lock.Lock()
+	if !c.jobs[job] {
+		c.lock.Unlock()
+		return false
+	}
+	message := c.removeJob(job)
+	c.lock.Unlock()
+
+	if emit && message != nil {
+		c.events <- createEventFromMessage(message, job)
+	}
+	return true
+}
+
+//removeJob removes job from c and returns its queued message, or nil if there was none.
+//c.lock must be held.
+func (c *Cron) removeJob(job *Job) *timequeue.Message {
+	delete(c.jobs, job)
+	return c.removeMessage(job)
 }
 
 func (c *Cron) SetJobSchedule(job *Job, sched sched.Schedule) bool {
-	return false
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.jobs[job] {
+		return false
+	}
+	c.setJobSchedule(job, sched, c.now())
+	return true
+}
+
+//setJobSchedule sets the schedule of job, which is in c. c.lock must be held.
+func (c *Cron) setJobSchedule(job *Job, s sched.Schedule, now time.Time) {
+	c.removeMessage(job)
+	job.Schedule = s
+	if c.running {
+		c.pushJob(job, now)
+	}
 }
 
 func (c *Cron) SetJobParseSchedule(job *Job, schedStr string) (bool, error) {
-	return false, nil
+	s, err := sched.Parse(schedStr)
+	if err != nil {
+		return false, err
+	}
+	return c.SetJobSchedule(job, s), nil
 }
 
+//Start starts releasing Events for the jobs in c.
+//The first time c is started, every job whose schedule is sched.IsReboot is released once.
+//When c is started again, every job with a MisfireRunOnce Spec that missed a time while c was
+//stopped is released once for that time.
 func (c *Cron) Start() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.running {
+		return
+	}
+	c.running = true
+	c.stop = make(chan struct{})
+
+	now := c.now()
+	pending := []*Event{}
+	for job := range c.jobs {
+		if !c.started && sched.IsReboot(job.Schedule) {
+			pending = append(pending, newEvent(job, now.In(c.location)))
+		}
+		if event, ok := c.misfireEvent(job, now); ok {
+			pending = append(pending, event)
+		}
+		c.pushJob(job, now)
+	}
+	c.started = true
+
+	c.done = make(chan struct{})
+	c.queue.Start()
+	go c.run(pending, c.stop, c.done)
 }
 
+//misfireEvent returns the Event for the first time job missed while c was stopped, if it
+//has a MisfireRunOnce Spec. c.lock must be held.
+func (c *Cron) misfireEvent(job *Job, now time.Time) (*Event, bool) {
+	if !c.started || job.Spec == nil || job.Spec.Misfire != MisfireRunOnce {
+		return nil, false
+	}
+	missed, ok := c.schedule(job).NextTime(c.stopped.In(c.location))
+	if !ok || !missed.Before(now) {
+		return nil, false
+	}
+	return newEvent(job, missed), true
+}
+
+//Stop stops releasing Events. No Events are released after it returns.
 func (c *Cron) Stop() {
+	c.lock.Lock()
+	if !c.running {
+		c.lock.Unlock()
+		return
+	}
+	c.running = false
+	c.stopped = c.now()
+	close(c.stop)
+	c.queue.Stop()
+	for job := range c.messages {
+		c.removeMessage(job)
+	}
+	done := c.done
+	c.lock.Unlock()
+
+	//the run must return so that it does not take messages from the queue when c is started
+	//again.
+	<-done
+}
+
+//SetCalendar sets the calendar whose excluded times every job in c skips, such as
+//company holidays. A nil calendar excludes nothing.
+func (c *Cron) SetCalendar(calendar sched.Calendar) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calendar = calendar
+	if !c.running {
+		return
+	}
+	now := c.now()
+	for job := range c.jobs {
+		c.removeMessage(job)
+		c.pushJob(job, now)
+	}
+}
+
+//Jobs returns the jobs in c in no particular order.
+func (c *Cron) Jobs() []*Job {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	result := make([]*Job, 0, len(c.jobs))
+	for job := range c.jobs {
+		result = append(result, job)
+	}
+	return result
 }
 
 func (c *Cron) IsRunning() bool {
-	return false
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.running
 }
 
 func (c *Cron) Events() <-chan *Event {
 	return c.events
 }
 
-func (c *Cron) run() {
+func (c *Cron) run(pending []*Event, stop, done chan struct{}) {
+	defer close(done)
+	for _, event := range pending {
+		if !c.emit(event, stop) {
+			return
+		}
+	}
+	for {
+		select {
+		case message := <-c.queue.Messages():
+			job, ok := c.popMessage(message)
+			if !ok {
+				continue
+			}
+			if !c.emit(createEventFromMessage(message, job), stop) {
+				return
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+//emit sends event on the events channel and returns false if stop is closed first.
+func (c *Cron) emit(event *Event, stop chan struct{}) bool {
+	select {
+	case c.events <- event:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+//popMessage returns the job message was pushed for, after pushing the job's next time,
+//and whether message is still current.
+func (c *Cron) popMessage(message *timequeue.Message) (*Job, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	job, ok := message.Data.(*Job)
+	if !ok || !c.running || c.messages[job] != message {
+		return nil, false
+	}
+	delete(c.messages, job)
+	if !c.pushJob(job, message.Time) {
+		//the schedule of job has no more times.
+		delete(c.jobs, job)
+	}
+	return job, true
+}
+
+//pushJob queues the next time of job after from and returns whether there was one.
+//c.lock must be held.
+func (c *Cron) pushJob(job *Job, from time.Time) bool {
+	next, ok := c.schedule(job).NextTime(from.In(c.location))
+	if !ok {
+		return false
+	}
+	c.messages[job] = c.queue.Push(next, job)
+	return true
+}
+
+//schedule returns the schedule of job with the calendar of c. c.lock must be held.
+func (c *Cron) schedule(job *Job) sched.Schedule {
+	if c.calendar == nil {
+		return job.Schedule
+	}
+	return sched.NewCalendarSchedule(job.Schedule, c.calendar)
+}
+
+//removeMessage removes the queued message of job and returns it, or nil if there was none.
+//c.lock must be held.
+func (c *Cron) removeMessage(job *Job) *timequeue.Message {
+	message, ok := c.messages[job]
+	if !ok {
+		return nil
+	}
+	delete(c.messages, job)
+	c.queue.Remove(message)
+	return message
 }
 
 func newJob(s sched.Schedule, data interface{}) *Job {
-	return nil
+	return &Job{
+		Schedule: s,
+		Data:     data,
+	}
 }
 
 func createEventFromMessage(message *timequeue.Message, job *Job) *Event {
-	return nil
+	return newEvent(job, message.Time)
 }
 
 func newEvent(job *Job, time time.Time) *Event {
-	return nil
+	return &Event{
+		Job:  job,
+		Time: time,
+	}
 }
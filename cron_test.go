@@ -0,0 +1,304 @@
+package cron
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogolfing/cron/sched"
+	"github.com/gogolfing/timequeue"
+)
+
+//fakeQueue is a queue that releases its earliest message when a test calls release instead
+//of at the message's time.
+type fakeQueue struct {
+	lock     sync.Mutex
+	pushed   []*timequeue.Message
+	messages chan *timequeue.Message
+	running  bool
+}
+
+func newFakeQueue() *fakeQueue {
+	return &fakeQueue{
+		messages: make(chan *timequeue.Message),
+	}
+}
+
+func (q *fakeQueue) Push(t time.Time, data interface{}) *timequeue.Message {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	message := &timequeue.Message{Time: t, Data: data}
+	q.pushed = append(q.pushed, message)
+	return message
+}
+
+func (q *fakeQueue) Remove(message *timequeue.Message) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for i, m := range q.pushed {
+		if m == message {
+			q.pushed = append(q.pushed[:i], q.pushed[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (q *fakeQueue) Messages() <-chan *timequeue.Message {
+	return q.messages
+}
+
+func (q *fakeQueue) Start() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.running = true
+}
+
+func (q *fakeQueue) Stop() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.running = false
+}
+
+//release sends the earliest pushed message to the Cron.
+func (q *fakeQueue) release(t *testing.T) {
+	t.Helper()
+	q.lock.Lock()
+	if len(q.pushed) == 0 {
+		q.lock.Unlock()
+		t.Fatal("release() with no pushed messages")
+	}
+	earliest := 0
+	for i, m := range q.pushed {
+		if m.Time.Before(q.pushed[earliest].Time) {
+			earliest = i
+		}
+	}
+	message := q.pushed[earliest]
+	q.pushed = append(q.pushed[:earliest], q.pushed[earliest+1:]...)
+	q.lock.Unlock()
+	select {
+	case q.messages <- message:
+	case <-time.After(time.Second):
+		t.Fatalf("release() of %v was not received", message.Time)
+	}
+}
+
+//times returns the times of the pushed messages in order.
+func (q *fakeQueue) times() []time.Time {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	result := []time.Time{}
+	for _, m := range q.pushed {
+		result = append(result, m.Time)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Before(result[j])
+	})
+	return result
+}
+
+var testStart = time.Date(2026, time.March, 4, 10, 0, 30, 0, time.UTC)
+
+//newTestCron returns a Cron in UTC with a fakeQueue whose clock returns *now.
+func newTestCron(now *time.Time) (*Cron, *fakeQueue) {
+	q := newFakeQueue()
+	c := NewCron(time.UTC)
+	c.queue = q
+	c.now = func() time.Time {
+		return *now
+	}
+	return c, q
+}
+
+func receive(t *testing.T, c *Cron) *Event {
+	t.Helper()
+	select {
+	case event := <-c.Events():
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("Events() did not release an event")
+	}
+	return nil
+}
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, time.March, 4, hour, minute, 0, 0, time.UTC)
+}
+
+func mustAdd(t *testing.T, c *Cron, expression string, data interface{}) *Job {
+	t.Helper()
+	job, err := c.Add(expression, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func testTimes(t *testing.T, q *fakeQueue, want ...time.Time) {
+	t.Helper()
+	if result := q.times(); !reflect.DeepEqual(result, append([]time.Time{}, want...)) {
+		t.Errorf("queued times = %v WANT %v", result, want)
+	}
+}
+
+func TestCron_Start(t *testing.T) {
+	now := testStart
+	c, q := newTestCron(&now)
+	mustAdd(t, c, "0 * * * * *", "a")
+	c.Start()
+	defer c.Stop()
+	if !c.IsRunning() || !q.running {
+		t.Fatalf("IsRunning() = %v, queue running = %v WANT true, true", c.IsRunning(), q.running)
+	}
+	testTimes(t, q, at(10, 1))
+
+	q.release(t)
+	event := receive(t, c)
+	if event.Data != "a" || !event.Time.Equal(at(10, 1)) {
+		t.Errorf("Event = %v, %v WANT a, %v", event.Data, event.Time, at(10, 1))
+	}
+	testTimes(t, q, at(10, 2))
+
+	mustAdd(t, c, "0 30 * * * *", "b")
+	testTimes(t, q, at(10, 2), at(10, 30))
+}
+
+func TestCron_Stop(t *testing.T) {
+	now := testStart
+	c, q := newTestCron(&now)
+	mustAdd(t, c, "0 * * * * *", "a")
+	c.Start()
+	c.Stop()
+	if c.IsRunning() || q.running {
+		t.Errorf("IsRunning() = %v, queue running = %v WANT false, false", c.IsRunning(), q.running)
+	}
+	testTimes(t, q)
+
+	mustAdd(t, c, "0 * * * * *", "b")
+	testTimes(t, q)
+
+	now = at(12, 0)
+	c.Start()
+	defer c.Stop()
+	testTimes(t, q, at(12, 1), at(12, 1))
+}
+
+func TestCron_Remove(t *testing.T) {
+	now := testStart
+	c, q := newTestCron(&now)
+	a := mustAdd(t, c, "0 * * * * *", "a")
+	b := mustAdd(t, c, "0 30 * * * *", "b")
+	c.Start()
+	defer c.Stop()
+
+	if result := c.Remove(a, false); !result {
+		t.Errorf("Remove(a, false) = %v WANT true", result)
+	}
+	if result := c.Remove(a, false); result {
+		t.Errorf("Remove(a, false) again = %v WANT false", result)
+	}
+	testTimes(t, q, at(10, 30))
+
+	removed := make(chan bool)
+	go func() {
+		removed <- c.Remove(b, true)
+	}()
+	event := receive(t, c)
+	if event.Job != b || !event.Time.Equal(at(10, 30)) {
+		t.Errorf("Remove(b, true) Event = %v, %v WANT b, %v", event.Data, event.Time, at(10, 30))
+	}
+	if result := <-removed; !result {
+		t.Errorf("Remove(b, true) = %v WANT true", result)
+	}
+	testTimes(t, q)
+	if jobs := c.Jobs(); len(jobs) != 0 {
+		t.Errorf("Jobs() = %v WANT []", jobs)
+	}
+}
+
+func TestCron_SetJobSchedule(t *testing.T) {
+	now := testStart
+	c, q := newTestCron(&now)
+	job := mustAdd(t, c, "0 * * * * *", "a")
+	c.Start()
+	defer c.Stop()
+
+	if result := c.SetJobSchedule(job, sched.MustParse("0 0 * * * *")); !result {
+		t.Errorf("SetJobSchedule() = %v WANT true", result)
+	}
+	testTimes(t, q, at(11, 0))
+
+	q.release(t)
+	if event := receive(t, c); event.Job != job || !event.Time.Equal(at(11, 0)) {
+		t.Errorf("Event = %v, %v WANT a, %v", event.Data, event.Time, at(11, 0))
+	}
+
+	other := newJob(sched.MustParse("0 0 * * * *"), "b")
+	if result := c.SetJobSchedule(other, sched.MustParse("0 * * * * *")); result {
+		t.Errorf("SetJobSchedule() of a job not in the Cron = %v WANT false", result)
+	}
+}
+
+func TestCron_Start_reboot(t *testing.T) {
+	now := testStart
+	c, q := newTestCron(&now)
+	mustAdd(t, c, "@reboot", "reboot")
+	mustAdd(t, c, "@reboot ; 0 0 * * * *", "union")
+	mustAdd(t, c, "CRON_TZ=America/New_York @reboot", "location")
+	mustAdd(t, c, "@reboot ~5m", "splay")
+	mustAdd(t, c, "0 * * * * *", "minutely")
+	c.Start()
+
+	released := []string{}
+	for i := 0; i < 4; i++ {
+		event := receive(t, c)
+		if !event.Time.Equal(now) {
+			t.Errorf("reboot Event %v Time = %v WANT %v", event.Data, event.Time, now)
+		}
+		released = append(released, event.Data.(string))
+	}
+	sort.Strings(released)
+	if want := []string{"location", "reboot", "splay", "union"}; !reflect.DeepEqual(released, want) {
+		t.Errorf("reboot Events = %v WANT %v", released, want)
+	}
+	testTimes(t, q, at(10, 1), at(11, 0))
+
+	//reboot jobs are only released the first time a Cron is started.
+	c.Stop()
+	c.Start()
+	defer c.Stop()
+	q.release(t)
+	if event := receive(t, c); event.Data != "minutely" {
+		t.Errorf("Event after restarting = %v WANT minutely", event.Data)
+	}
+}
+
+func TestCron_Start_misfire(t *testing.T) {
+	now := testStart
+	c, q := newTestCron(&now)
+	hourly := "0 0 * * * *"
+	_, err := c.Apply([]*JobSpec{
+		{ID: "once", Schedule: hourly, Misfire: MisfireRunOnce},
+		{ID: "skip", Schedule: hourly},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	c.Stop()
+
+	now = at(13, 30)
+	c.Start()
+	defer c.Stop()
+	if event := receive(t, c); event.ID != "once" || !event.Time.Equal(at(11, 0)) {
+		t.Errorf("misfire Event = %v, %v WANT once, %v", event.ID, event.Time, at(11, 0))
+	}
+	testTimes(t, q, at(14, 0), at(14, 0))
+	q.release(t)
+	if event := receive(t, c); !event.Time.Equal(at(14, 0)) {
+		t.Errorf("Event after misfire = %v, %v WANT %v", event.ID, event.Time, at(14, 0))
+	}
+}
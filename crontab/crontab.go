@@ -0,0 +1,365 @@
+//Package crontab parses crontab files into sched.Schedules and the commands they run.
+//
+//A crontab is a list of lines. Blank lines and lines starting with "#" are ignored, lines
+//such as "MAILTO=ops@example.com" assign environment variables, and every other line is an
+//entry with a schedule followed by a command:
+//
+//	CRON_TZ=America/New_York
+//	30 2 * * 1-5 /usr/bin/backup --full
+//	@hourly      rotate-logs%now
+//
+//Environment assignments apply to the entries after them, and CRON_TZ sets the time zone
+//that the schedules of those entries are in. The entries of system crontabs have the user to
+//run the command as between the schedule and the command, as in "/etc/crontab".
+//
+//An unescaped "%" in a command ends the command, and the text after it is the standard input
+//of the command with each further unescaped "%" replaced by a newline. "\%" is a literal "%".
+package crontab
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gogolfing/cron"
+	"github.com/gogolfing/cron/sched"
+)
+
+const (
+	//Comment starts a comment line.
+	Comment = "#"
+
+	//Percent separates a command from its standard input, and the lines of the input.
+	Percent = "%"
+
+	//TimeZone is the environment variable that sets the time zone of the schedules after it.
+	TimeZone = "CRON_TZ"
+
+	//DefaultFields is the number of fields in the schedule of an entry that is not a
+	//directive.
+	DefaultFields = 5
+)
+
+//Entry is a single scheduled command in a crontab.
+type Entry struct {
+	//Line is the line number of the entry, starting at 1.
+	Line int
+
+	//Expression is the schedule of the entry as written, and Schedule is it parsed.
+	//Schedule is a *sched.LocationSchedule if the entry is after a CRON_TZ assignment.
+	Expression string
+	Schedule   sched.Schedule
+
+	//User is the user to run Command as. It is only set in system crontabs.
+	User string
+
+	//Command is the command to run, and Input is its standard input from after the first
+	//unescaped Percent.
+	Command string
+	Input   string
+
+	//Env is the environment assigned before the entry.
+	Env map[string]string
+}
+
+//Crontab is the parsed contents of a crontab.
+type Crontab struct {
+	Entries []*Entry
+
+	//Env is the environment assigned by the whole crontab.
+	Env map[string]string
+}
+
+//Error is an error on a line of a crontab.
+type Error struct {
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("crontab: line %v: %v", e.Line, e.Err.Error())
+}
+
+//Unwrap returns the underlying error, such as a *sched.ParseError.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+//Parser parses crontabs.
+//The zero value is ready to use and parses user crontabs like Parse.
+type Parser struct {
+	//System is whether entries have a user field after their schedules, as in /etc/crontab.
+	System bool
+
+	//Fields is the number of fields in schedules that are not directives.
+	//If zero, DefaultFields is used.
+	Fields int
+
+	//Sched parses the schedules of entries.
+	//If nil, schedules are parsed like sched.Parse.
+	Sched *sched.Parser
+}
+
+//Parse parses the user crontab read from r.
+func Parse(r io.Reader) (*Crontab, error) {
+	return (&Parser{}).Parse(r)
+}
+
+//ParseSystem parses the system crontab read from r, whose entries have a user field.
+func ParseSystem(r io.Reader) (*Crontab, error) {
+	return (&Parser{System: true}).Parse(r)
+}
+
+//Parse parses the crontab read from r.
+//The error is an *Error for the first invalid line, or an error reading r.
+func (p *Parser) Parse(r io.Reader) (*Crontab, error) {
+	result := &Crontab{
+		Entries: []*Entry{},
+	}
+	scanner := NewScanner(r)
+	for scanner.Scan() {
+		if err := scanner.LineErr(); err != nil {
+			return nil, &Error{scanner.Line(), err}
+		}
+		entry, err := p.parseEntry(scanner.Text(), scanner.Location())
+		if err != nil {
+			return nil, &Error{scanner.Line(), err}
+		}
+		entry.Line = scanner.Line()
+		entry.Env = copyEnv(scanner.Env())
+		result.Entries = append(result.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	result.Env = scanner.Env()
+	return result, nil
+}
+
+//Scanner reads the entries of a crontab one line at a time, and keeps the environment that
+//the assignments before each entry set.
+type Scanner struct {
+	scanner *bufio.Scanner
+	line    int
+	text    string
+	lineErr error
+	env     map[string]string
+	loc     *time.Location
+}
+
+//NewScanner returns a Scanner that reads from r.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{
+		scanner: bufio.NewScanner(r),
+		env:     map[string]string{},
+	}
+}
+
+//Scan advances to the next line that is an entry or an invalid assignment, skipping blank
+//lines and comments and applying the assignments before it.
+//It returns false at the end of the input or if reading it fails.
+func (s *Scanner) Scan() bool {
+	for s.scanner.Scan() {
+		s.line++
+		s.text = strings.TrimSpace(s.scanner.Text())
+		s.lineErr = nil
+		if s.text == "" || strings.HasPrefix(s.text, Comment) {
+			continue
+		}
+		name, value, ok := ParseAssignment(s.text)
+		if !ok {
+			return true
+		}
+		if name == TimeZone {
+			loc, err := time.LoadLocation(value)
+			if err != nil {
+				s.lineErr = fmt.Errorf("%v: %v", TimeZone, err.Error())
+				return true
+			}
+			s.loc = loc
+		}
+		s.env[name] = value
+	}
+	return false
+}
+
+//Line returns the number of the current line, starting at 1.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+//Text returns the current line without its surrounding whitespace.
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+//LineErr returns the error of the current line if it is an invalid assignment, such as of an
+//unknown time zone to CRON_TZ, and otherwise nil.
+func (s *Scanner) LineErr() error {
+	return s.lineErr
+}
+
+//Env returns the environment assigned before the current line, or by the whole crontab once
+//Scan returns false. It must not be modified.
+func (s *Scanner) Env() map[string]string {
+	return s.env
+}
+
+//Location returns the time zone set by the last CRON_TZ assignment before the current line,
+//or nil if there is none.
+func (s *Scanner) Location() *time.Location {
+	return s.loc
+}
+
+//Err returns the first error reading the input, other than io.EOF.
+func (s *Scanner) Err() error {
+	return s.scanner.Err()
+}
+
+//parseEntry parses the entry text whose schedule is in loc, which may be nil.
+func (p *Parser) parseEntry(text string, loc *time.Location) (*Entry, error) {
+	expression, rest, err := p.SplitEntry(text)
+	if err != nil {
+		return nil, err
+	}
+	result := &Entry{Expression: expression}
+	s, err := p.parseSchedule(result.Expression)
+	if err != nil {
+		return nil, err
+	}
+	result.Schedule = s
+	if _, isReboot := s.(sched.RebootSchedule); loc != nil && !isReboot {
+		result.Schedule = sched.NewLocationSchedule(s, loc)
+	}
+	if p.System {
+		users, command := splitFields(rest, 1)
+		if len(users) == 0 {
+			return nil, fmt.Errorf("user is missing")
+		}
+		result.User, rest = users[0], command
+	}
+	if rest == "" {
+		return nil, fmt.Errorf("command is missing")
+	}
+	result.Command, result.Input = splitCommand(rest)
+	return result, nil
+}
+
+//SplitEntry splits the entry text into its schedule expression and the rest of the entry
+//after it, which is the user and command. The schedule is not parsed, so that it can be
+//checked in other ways, such as with sched.Lint.
+func (p *Parser) SplitEntry(text string) (string, string, error) {
+	count := p.scheduleFieldCount(sched.Fields(text))
+	fields, rest := splitFields(text, count)
+	if len(fields) < count {
+		return "", "", fmt.Errorf("schedule must have %v fields", count)
+	}
+	return strings.Join(fields, " "), rest, nil
+}
+
+func (p *Parser) parseSchedule(expression string) (sched.Schedule, error) {
+	if p.Sched == nil {
+		return sched.Parse(expression)
+	}
+	return p.Sched.Parse(expression)
+}
+
+//scheduleFieldCount returns the number of fields at the start of an entry that are its
+//schedule.
+func (p *Parser) scheduleFieldCount(fields []string) int {
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "@") {
+		if p.Fields == 0 {
+			return DefaultFields
+		}
+		return p.Fields
+	}
+	switch strings.ToLower(fields[0]) {
+	case sched.Every:
+		if len(fields) > 2 && (strings.EqualFold(fields[2], sched.From) || strings.EqualFold(fields[2], sched.Offset)) {
+			return 4
+		}
+		return 2
+	case sched.At:
+		return 2
+	}
+	return 1
+}
+
+//splitFields returns the first count fields of text and the rest of text after them, without
+//leading whitespace.
+func splitFields(text string, count int) ([]string, string) {
+	fields := []string{}
+	rest := strings.TrimLeftFunc(text, unicode.IsSpace)
+	for len(fields) < count && rest != "" {
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+		fields = append(fields, rest[:end])
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+	}
+	return fields, rest
+}
+
+//splitCommand splits text at its first unescaped Percent into the command and its standard
+//input, in which the other unescaped Percents are newlines.
+func splitCommand(text string) (string, string) {
+	command, input := &strings.Builder{}, &strings.Builder{}
+	current, hasInput := command, false
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && strings.HasPrefix(text[i+1:], Percent):
+			current.WriteString(Percent)
+			i += len(Percent)
+		case strings.HasPrefix(text[i:], Percent) && !hasInput:
+			current, hasInput = input, true
+		case strings.HasPrefix(text[i:], Percent):
+			current.WriteString("\n")
+		default:
+			current.WriteByte(text[i])
+		}
+	}
+	return strings.TrimRightFunc(command.String(), unicode.IsSpace), input.String()
+}
+
+//ParseAssignment returns the name and value of the line text if it is an environment
+//assignment, such as CRON_TZ=UTC. Values may be quoted.
+func ParseAssignment(text string) (string, string, bool) {
+	index := strings.Index(text, "=")
+	if index <= 0 {
+		return "", "", false
+	}
+	name := strings.TrimSpace(text[:index])
+	for i, r := range name {
+		isLetter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return "", "", false
+		}
+	}
+	value := strings.TrimSpace(text[index+1:])
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return name, value, true
+}
+
+func copyEnv(env map[string]string) map[string]string {
+	result := make(map[string]string, len(env))
+	for name, value := range env {
+		result[name] = value
+	}
+	return result
+}
+
+//AddTo adds a Job for each entry of t to c, with the Command of the entry as its Data.
+//The Jobs are returned in the same order as t.Entries.
+func (t *Crontab) AddTo(c *cron.Cron) []*cron.Job {
+	result := make([]*cron.Job, 0, len(t.Entries))
+	for _, entry := range t.Entries {
+		result = append(result, c.AddSchedule(entry.Schedule, entry.Command))
+	}
+	return result
+}
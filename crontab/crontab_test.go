@@ -0,0 +1,243 @@
+package crontab
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogolfing/cron"
+	"github.com/gogolfing/cron/sched"
+)
+
+const testCrontab = `# backups
+MAILTO = "ops@example.com"
+30 2 * * 1-5   /usr/bin/backup --full
+
+CRON_TZ=America/New_York
+@hourly rotate-logs%now%later
+  @every 15m from 00:05 poll
+0 9 1 * * echo 100\% done > /tmp/out
+@reboot start-agent
+`
+
+func TestParse(t *testing.T) {
+	tab, err := Parse(strings.NewReader(testCrontab))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	tests := []struct {
+		line       int
+		expression string
+		command    string
+		input      string
+		zoned      bool
+	}{
+		{3, "30 2 * * 1-5", "/usr/bin/backup --full", "", false},
+		{6, "@hourly", "rotate-logs", "now\nlater", true},
+		{7, "@every 15m from 00:05", "poll", "", true},
+		{8, "0 9 1 * *", "echo 100% done > /tmp/out", "", true},
+		{9, "@reboot", "start-agent", "", false},
+	}
+	if len(tab.Entries) != len(tests) {
+		t.Fatalf("len(Parse().Entries) = %v WANT %v", len(tab.Entries), len(tests))
+	}
+	for i, test := range tests {
+		e := tab.Entries[i]
+		if e.Line != test.line || e.Expression != test.expression || e.Command != test.command || e.Input != test.input {
+			t.Errorf("Entries[%v] = %v, %q, %q, %q WANT %v, %q, %q, %q",
+				i, e.Line, e.Expression, e.Command, e.Input, test.line, test.expression, test.command, test.input,
+			)
+		}
+		if _, zoned := e.Schedule.(*sched.LocationSchedule); zoned != test.zoned {
+			t.Errorf("Entries[%v].Schedule = %T WANT zoned %v", i, e.Schedule, test.zoned)
+		}
+	}
+	if want := map[string]string{"MAILTO": "ops@example.com"}; !reflect.DeepEqual(tab.Entries[0].Env, want) {
+		t.Errorf("Entries[0].Env = %v WANT %v", tab.Entries[0].Env, want)
+	}
+	want := map[string]string{"MAILTO": "ops@example.com", "CRON_TZ": "America/New_York"}
+	if !reflect.DeepEqual(tab.Entries[1].Env, want) || !reflect.DeepEqual(tab.Env, want) {
+		t.Errorf("Entries[1].Env, Env = %v, %v WANT %v", tab.Entries[1].Env, tab.Env, want)
+	}
+	if _, ok := tab.Entries[4].Schedule.(sched.RebootSchedule); !ok {
+		t.Errorf("Entries[4].Schedule = %T WANT sched.RebootSchedule", tab.Entries[4].Schedule)
+	}
+}
+
+func TestParse_location(t *testing.T) {
+	tab, err := Parse(strings.NewReader("CRON_TZ=America/New_York\n0 9 * * * report\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	from := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	want := time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC)
+	if next, ok := tab.Entries[0].Schedule.NextTime(from); !ok || !next.Equal(want) {
+		t.Errorf("NextTime(%v) = %v, %v WANT %v, true", from, next, ok, want)
+	}
+}
+
+func TestParseSystem(t *testing.T) {
+	tab, err := ParseSystem(strings.NewReader("17 * * * * root cd / && run-parts /etc/cron.hourly\n"))
+	if err != nil {
+		t.Fatalf("ParseSystem() error = %v", err)
+	}
+	e := tab.Entries[0]
+	if e.User != "root" || e.Command != "cd / && run-parts /etc/cron.hourly" {
+		t.Errorf("Entries[0] = %q, %q WANT root, cd / && run-parts /etc/cron.hourly", e.User, e.Command)
+	}
+}
+
+func TestParser_Parse_fields(t *testing.T) {
+	p := &Parser{Fields: 6}
+	tab, err := p.Parse(strings.NewReader("*/10 * * * * * tick\n"))
+	if err != nil || tab.Entries[0].Expression != "*/10 * * * * *" || tab.Entries[0].Command != "tick" {
+		t.Errorf("Parse() = %v, %v WANT a 6 field schedule", tab, err)
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	tests := []struct {
+		text   string
+		system bool
+		err    string
+	}{
+		{"0 0 * *\n", false, "crontab: line 1: schedule must have 5 fields"},
+		{"# comment\n0 0 * * *\n", false, "crontab: line 2: command is missing"},
+		{"0 0 * * * cmd\n", true, "crontab: line 1: command is missing"},
+		{"@daily\n", true, "crontab: line 1: user is missing"},
+		{"CRON_TZ=Nowhere/Nothing\n", false, "crontab: line 1: CRON_TZ: unknown time zone Nowhere/Nothing"},
+		{"0 70 * * * cmd\n", false, `crontab: line 1: sched: could not parse "0 70 * * *": hour field: not in range`},
+	}
+	for _, test := range tests {
+		_, err := (&Parser{System: test.system}).Parse(strings.NewReader(test.text))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Parse(%q) error = %v WANT %v", test.text, err, test.err)
+		}
+	}
+}
+
+func TestParse_errorUnwrap(t *testing.T) {
+	_, err := Parse(strings.NewReader("\n0 70 * * * cmd\n"))
+	var ce *Error
+	var pe *sched.ParseError
+	if !errors.As(err, &ce) || ce.Line != 2 || !errors.As(err, &pe) {
+		t.Errorf("Parse() error = %#v WANT *Error on line 2 wrapping *sched.ParseError", err)
+	}
+}
+
+func TestScanner(t *testing.T) {
+	type line struct {
+		number   int
+		text     string
+		location string
+		err      bool
+	}
+	text := "# comment\nMAILTO=ops\n0 0 * * * a\nCRON_TZ=Nowhere/Nothing\n\nCRON_TZ=UTC\n  @daily b  \n"
+	scanner := NewScanner(strings.NewReader(text))
+	result := []line{}
+	for scanner.Scan() {
+		l := line{scanner.Line(), scanner.Text(), "", scanner.LineErr() != nil}
+		if loc := scanner.Location(); loc != nil {
+			l.location = loc.String()
+		}
+		result = append(result, l)
+	}
+	want := []line{
+		{3, "0 0 * * * a", "", false},
+		{4, "CRON_TZ=Nowhere/Nothing", "", true},
+		{7, "@daily b", "UTC", false},
+	}
+	if !reflect.DeepEqual(result, want) || scanner.Err() != nil {
+		t.Errorf("Scan() = %v, %v WANT %v, <nil>", result, scanner.Err(), want)
+	}
+	if env := scanner.Env(); !reflect.DeepEqual(env, map[string]string{"MAILTO": "ops", TimeZone: "UTC"}) {
+		t.Errorf("Env() = %v WANT MAILTO and CRON_TZ", env)
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		text    string
+		command string
+		input   string
+	}{
+		{"cmd", "cmd", ""},
+		{"cmd %", "cmd", ""},
+		{"mail -s hi root%line 1%line 2%", "mail -s hi root", "line 1\nline 2\n"},
+		{`date +\%F`, "date +%F", ""},
+		{`cat%a\%b%c`, "cat", "a%b\nc"},
+		{`back\slash`, `back\slash`, ""},
+	}
+	for _, test := range tests {
+		command, input := splitCommand(test.text)
+		if command != test.command || input != test.input {
+			t.Errorf("splitCommand(%q) = %q, %q WANT %q, %q", test.text, command, input, test.command, test.input)
+		}
+	}
+}
+
+func TestParseAssignment(t *testing.T) {
+	tests := []struct {
+		text  string
+		name  string
+		value string
+		ok    bool
+	}{
+		{"CRON_TZ=UTC", "CRON_TZ", "UTC", true},
+		{`MAILTO = "ops@example.com"`, "MAILTO", "ops@example.com", true},
+		{"PATH='/bin:/usr/bin'", "PATH", "/bin:/usr/bin", true},
+		{"EMPTY=", "EMPTY", "", true},
+		{"0 0 * * * FOO=bar cmd", "", "", false},
+		{"=value", "", "", false},
+	}
+	for _, test := range tests {
+		name, value, ok := ParseAssignment(test.text)
+		if name != test.name || value != test.value || ok != test.ok {
+			t.Errorf("ParseAssignment(%q) = %q, %q, %v WANT %q, %q, %v", test.text, name, value, ok, test.name, test.value, test.ok)
+		}
+	}
+}
+
+func TestParser_SplitEntry(t *testing.T) {
+	tests := []struct {
+		fields     int
+		text       string
+		expression string
+		rest       string
+		err        string
+	}{
+		{0, "30 2 * * 1-5 /usr/bin/backup --full", "30 2 * * 1-5", "/usr/bin/backup --full", ""},
+		{0, "@every  15m from 00:05   poll", "@every 15m from 00:05", "poll", ""},
+		{0, "@hourly", "@hourly", "", ""},
+		{6, "0 30 2 * * 1-5 root backup", "0 30 2 * * 1-5", "root backup", ""},
+		{0, "0 0 * *", "", "", "schedule must have 5 fields"},
+	}
+	for _, test := range tests {
+		expression, rest, err := (&Parser{Fields: test.fields}).SplitEntry(test.text)
+		message := ""
+		if err != nil {
+			message = err.Error()
+		}
+		if expression != test.expression || rest != test.rest || message != test.err {
+			t.Errorf("SplitEntry(%q) = %q, %q, %v WANT %q, %q, %v", test.text, expression, rest, err, test.expression, test.rest, test.err)
+		}
+	}
+}
+
+func TestCrontab_AddTo(t *testing.T) {
+	tab, err := Parse(strings.NewReader(testCrontab))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	jobs := tab.AddTo(cron.NewCron(time.UTC))
+	if len(jobs) != len(tab.Entries) {
+		t.Fatalf("len(AddTo()) = %v WANT %v", len(jobs), len(tab.Entries))
+	}
+	for i, job := range jobs {
+		if job.Data != tab.Entries[i].Command || job.Schedule != tab.Entries[i].Schedule {
+			t.Errorf("AddTo()[%v] = %v, %v WANT %v, %v", i, job.Schedule, job.Data, tab.Entries[i].Schedule, tab.Entries[i].Command)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+//Package jobfile decodes YAML and TOML jobs files for a reload.Watcher, which only decodes
+//JSON so that package reload depends on nothing outside of the standard library:
+//
+//	w := reload.NewWatcher("jobs.yaml", c)
+//	w.Decode = jobfile.DecoderFor(w.Path)
+//
+//The files declare each job as a cron.JobSpec, in a list or in the "jobs" list of a mapping
+//or table, and fields that a JobSpec does not have are errors.
+package jobfile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gogolfing/cron"
+	"github.com/gogolfing/cron/reload"
+	"gopkg.in/yaml.v3"
+)
+
+//jobsFile is a jobs file that is a mapping or table.
+type jobsFile struct {
+	Jobs []*cron.JobSpec `yaml:"jobs" toml:"jobs"`
+}
+
+//DecodeYAML decodes a YAML sequence of JobSpecs, or a mapping with a "jobs" sequence.
+func DecodeYAML(data []byte) ([]*cron.JobSpec, error) {
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(data, root); err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if len(root.Content) > 0 && root.Content[0].Kind == yaml.SequenceNode {
+		result := []*cron.JobSpec{}
+		return result, decoder.Decode(&result)
+	}
+	file := &jobsFile{}
+	if err := decoder.Decode(file); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return file.Jobs, nil
+}
+
+//DecodeTOML decodes the JobSpecs of a TOML "jobs" array of tables.
+func DecodeTOML(data []byte) ([]*cron.JobSpec, error) {
+	file := &jobsFile{}
+	md, err := toml.Decode(string(data), file)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range md.Undecoded() {
+		//the keys of tables in data are decoded into the data itself.
+		if len(key) > 2 && key[0] == "jobs" && key[1] == "data" {
+			continue
+		}
+		return nil, fmt.Errorf("unknown field %q", key.String())
+	}
+	return file.Jobs, nil
+}
+
+//DecoderFor returns the reload.DecodeFunc for the extension of path: DecodeYAML for ".yaml"
+//and ".yml", DecodeTOML for ".toml", and otherwise reload.DecodeJSON.
+func DecoderFor(path string) reload.DecodeFunc {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return DecodeYAML
+	case ".toml":
+		return DecodeTOML
+	}
+	return reload.DecodeJSON
+}
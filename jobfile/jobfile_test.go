@@ -0,0 +1,66 @@
+package jobfile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gogolfing/cron"
+	"github.com/gogolfing/cron/reload"
+)
+
+func TestDecoderFor(t *testing.T) {
+	tests := []struct {
+		path     string
+		contents string
+	}{
+		{"jobs.json", `{"jobs": [{"id": "backup", "schedule": "@daily", "jitter": "5m", "data": {"path": "/usr/bin/backup"}}]}`},
+		{"jobs.yaml", "- id: backup\n  schedule: \"@daily\"\n  jitter: 5m\n  data:\n    path: /usr/bin/backup\n"},
+		{"jobs.yml", "jobs:\n  - id: backup\n    schedule: \"@daily\"\n    jitter: 5m\n    data: {path: /usr/bin/backup}\n"},
+		{"jobs.toml", "[[jobs]]\nid = \"backup\"\nschedule = \"@daily\"\njitter = \"5m\"\n[jobs.data]\npath = \"/usr/bin/backup\"\n"},
+	}
+	for _, test := range tests {
+		specs, err := DecoderFor(test.path)([]byte(test.contents))
+		if err != nil || len(specs) != 1 {
+			t.Errorf("DecoderFor(%v)() = %v, %v WANT one spec", test.path, specs, err)
+			continue
+		}
+		spec := specs[0]
+		data := map[string]interface{}{"path": "/usr/bin/backup"}
+		if spec.ID != "backup" || spec.Schedule != "@daily" || spec.Jitter != cron.Duration(5*time.Minute) || !reflect.DeepEqual(spec.Data, data) {
+			t.Errorf("DecoderFor(%v)() = %+v WANT backup @daily with 5m jitter and %v", test.path, spec, data)
+		}
+	}
+}
+
+func TestDecoderFor_unknownFields(t *testing.T) {
+	tests := []struct {
+		path     string
+		contents string
+	}{
+		{"jobs.json", `[{"id": "backup", "schedule": "@daily", "color": "red"}]`},
+		{"jobs.yaml", "- id: backup\n  schedule: \"@daily\"\n  color: red\n"},
+		{"jobs.toml", "[[jobs]]\nid = \"backup\"\nschedule = \"@daily\"\ncolor = \"red\"\n"},
+		{"jobs.toml", "[[job]]\nid = \"backup\"\n"},
+	}
+	for _, test := range tests {
+		if _, err := DecoderFor(test.path)([]byte(test.contents)); err == nil {
+			t.Errorf("DecoderFor(%v)(%q) error = <nil> WANT an error", test.path, test.contents)
+		}
+	}
+}
+
+func TestDecoderFor_watcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.yaml")
+	if err := os.WriteFile(path, []byte("- id: backup\n  schedule: \"@daily\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w := reload.NewWatcher(path, cron.NewCron(time.UTC))
+	w.Decode = DecoderFor(path)
+	result, err := w.Load()
+	if want := (&cron.ApplyResult{Added: []string{"backup"}}); err != nil || !reflect.DeepEqual(result, want) {
+		t.Errorf("Load() = %+v, %v WANT %+v, <nil>", result, err, want)
+	}
+}
@@ -0,0 +1,831 @@
+//Package natural parses English descriptions of schedules, such as "every weekday at 9am"
+//or "every 15 minutes between 9 and 17", into cron expressions and sched.Schedules.
+//
+//A description is a sequence of clauses in any order:
+//
+//	every second|minute|hour|day|week|month|year
+//	every N seconds|minutes|hours|days|weeks|months
+//	every other second|minute|hour|day|week|month
+//	every weekday|weekend|monday|...
+//	hourly, daily, weekly, monthly, yearly
+//	at 9am, at 9:30 and 17:00, at noon, at midnight
+//	at minute 30, at second 15
+//	between 9 and 17, from 9am to 5pm, from monday to friday
+//	on monday and friday, on weekdays, on weekends
+//	on the 1st and 15th, on day 10, on the last day of the month
+//	on the last weekday of the month, on the last friday, on the second tuesday
+//	in january, in march through may, in 2027
+//
+//Ranges, such as "between 9 and 17", include both ends like cron ranges do.
+//Ranges of times of day, such as "from 9am to 5pm" or "between 9:00 and 17:00", end at
+//their last time instead, and must start and end on the hour.
+//Times of day that are not given default to midnight, and the seconds and minutes under
+//the largest time of day unit that is given default to zero.
+//Steps apply to the range of their unit if one is given, as in "every 2 hours between 9
+//and 17".
+//
+//Steps that do not fit in their cron field, such as "every 90 minutes" or "every 40 days",
+//and steps of weeks compile to Every expressions on a grid of wall clock times, such as
+//"@every 1h30m0s offset 0s". They cannot be given with other clauses, except that steps
+//of weeks can be given with a single weekday and time of day, as in "every 2 weeks on
+//monday at 9am".
+package natural
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogolfing/cron/sched"
+)
+
+var weekdays = [...]string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+var months = [...]string{
+	"january", "february", "march", "april", "may", "june",
+	"july", "august", "september", "october", "november", "december",
+}
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+//epochWeekday is the weekday of January 1, 1970, which intervals are anchored to.
+const epochWeekday = int(time.Thursday)
+
+//fieldSpans are the number of values in each field that can have a step.
+var fieldSpans = [sched.FieldCount]int{
+	sched.SecondField: sched.MaxSecond - sched.MinSecond + 1,
+	sched.MinuteField: sched.MaxMinute - sched.MinMinute + 1,
+	sched.HourField:   sched.MaxHour - sched.MinHour + 1,
+	sched.DomField:    sched.MaxDom - sched.MinDom + 1,
+	sched.MonthField:  sched.MaxMonth - sched.MinMonth + 1,
+}
+
+var ordinalWords = [...]string{"", "first", "second", "third", "fourth", "fifth"}
+
+var (
+	clockRegexp   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+	ordinalRegexp = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)$`)
+)
+
+//Parse parses text into a Schedule. The Expression of the result is the canonical cron
+//expression of text so that users can confirm what they meant.
+func Parse(text string) (sched.Schedule, error) {
+	expression, err := Compile(text)
+	if err != nil {
+		return nil, err
+	}
+	return sched.Parse(expression)
+}
+
+//Compile returns the cron expression that text describes.
+//Times of day with different minutes or seconds result in composite expressions, such as
+//"0 30 9 * * * *; 0 0 17 * * * *".
+func Compile(text string) (string, error) {
+	p := newParser(text)
+	if p.done() {
+		return "", fmt.Errorf("natural: description cannot be empty")
+	}
+	for !p.done() {
+		if err := p.parseClause(); err != nil {
+			return "", err
+		}
+	}
+	return p.expression()
+}
+
+//clock is a time of day.
+type clock struct {
+	hour   int
+	minute int
+	second int
+
+	//hourOnly is whether the clock is a bare hour, such as 17, instead of a time of day.
+	hourOnly bool
+}
+
+type parser struct {
+	tokens []string
+	index  int
+
+	//fields are the values of the cron fields that have been given.
+	fields [sched.FieldCount]string
+
+	//times are the times of day that have been given.
+	times []clock
+
+	//steps are the steps of the cron fields that have been given with "every". They apply
+	//to the range of their field if one is given.
+	steps [sched.FieldCount]int
+
+	//interval is the interval of an "every" clause that cron steps cannot express, such as
+	//"every 90 minutes", and intervalText is that clause.
+	interval     time.Duration
+	intervalText string
+
+	//period is "week", "month", or "year" if it has been given without a day.
+	period string
+}
+
+func newParser(text string) *parser {
+	text = strings.ToLower(text)
+	text = strings.NewReplacer(",", " , ", "-", " - ").Replace(text)
+	return &parser{
+		tokens: strings.Fields(text),
+	}
+}
+
+func (p *parser) done() bool {
+	return p.index >= len(p.tokens)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() string {
+	result := p.peek()
+	p.index++
+	return result
+}
+
+//accept consumes the next token and returns true if it is one of words.
+func (p *parser) accept(words ...string) bool {
+	for _, word := range words {
+		if p.peek() == word {
+			p.index++
+			return true
+		}
+	}
+	return false
+}
+
+//acceptListSeparator accepts ",", "and", or ", and" if the token after it is an item of
+//the list.
+func (p *parser) acceptListSeparator(isItem func(token string) bool) bool {
+	start := p.index
+	if p.accept(",") {
+		p.accept("and")
+	} else if !p.accept("and") {
+		return false
+	}
+	if isItem(p.peek()) {
+		return true
+	}
+	p.index = start
+	return false
+}
+
+func (p *parser) acceptRangeSeparator() bool {
+	return p.accept("-", "to", "through", "thru", "until")
+}
+
+func (p *parser) unexpected() error {
+	if p.done() {
+		return fmt.Errorf("natural: unexpected end of description")
+	}
+	return fmt.Errorf("natural: unexpected %q", p.peek())
+}
+
+func (p *parser) set(fi int, value string) error {
+	if p.fields[fi] != "" {
+		return fmt.Errorf("natural: %v is given more than once", sched.FieldName(fi))
+	}
+	p.fields[fi] = value
+	return nil
+}
+
+func (p *parser) parseClause() error {
+	switch token := p.next(); token {
+	case "every", "each":
+		return p.parseEvery()
+	case "secondly":
+		return p.setStep(sched.SecondField, 1, time.Second, p.index-1)
+	case "minutely":
+		return p.setStep(sched.MinuteField, 1, time.Minute, p.index-1)
+	case "hourly":
+		return p.setStep(sched.HourField, 1, time.Hour, p.index-1)
+	case "daily", "nightly":
+		return nil
+	case "weekly":
+		return p.setPeriod("week")
+	case "monthly":
+		return p.setPeriod("month")
+	case "yearly", "annually":
+		return p.setPeriod("year")
+	case "at":
+		return p.parseAt()
+	case "between", "from":
+		return p.parseBetween()
+	case "on":
+		return p.parseOn()
+	case "in", "during":
+		return p.parseIn()
+	case ",", "and":
+		return nil
+	}
+	p.index--
+	if _, ok := weekdayValue(p.peek()); ok || isWeekdaysWord(p.peek()) || isWeekendsWord(p.peek()) {
+		return p.parseWeekdays()
+	}
+	return p.unexpected()
+}
+
+func (p *parser) setPeriod(period string) error {
+	if p.period != "" {
+		return fmt.Errorf("natural: %q is given more than once", "every "+period)
+	}
+	p.period = period
+	return nil
+}
+
+func (p *parser) parseEvery() error {
+	start := p.index - 1
+	n := 1
+	if p.accept("other") {
+		n = 2
+	} else if value, err := strconv.Atoi(p.peek()); err == nil {
+		if value <= 0 {
+			return fmt.Errorf("natural: %q must be positive", p.peek())
+		}
+		p.next()
+		n = value
+	}
+	unit := p.peek()
+	if n > 1 {
+		unit = strings.TrimSuffix(unit, "s")
+	}
+	switch unit {
+	case "second", "sec":
+		p.next()
+		return p.setStep(sched.SecondField, n, time.Second, start)
+	case "minute", "min":
+		p.next()
+		return p.setStep(sched.MinuteField, n, time.Minute, start)
+	case "hour":
+		p.next()
+		return p.setStep(sched.HourField, n, time.Hour, start)
+	case "day", "night":
+		p.next()
+		if n > 1 {
+			return p.setStep(sched.DomField, n, day, start)
+		}
+		return nil
+	case "week":
+		p.next()
+		if n > 1 {
+			return p.setInterval(time.Duration(n)*week, start)
+		}
+		return p.setPeriod(unit)
+	case "month":
+		p.next()
+		if n > fieldSpans[sched.MonthField] {
+			return fmt.Errorf("natural: every %v %vs is not supported", n, unit)
+		}
+		if n > 1 {
+			if err := p.setStep(sched.MonthField, n, 0, start); err != nil {
+				return err
+			}
+		}
+		return p.setPeriod(unit)
+	case "year":
+		p.next()
+		if n > 1 {
+			return fmt.Errorf("natural: every %v %vs is not supported", n, unit)
+		}
+		return p.setPeriod(unit)
+	}
+	if n > 1 {
+		return p.unexpected()
+	}
+	return p.parseWeekdays()
+}
+
+//setStep sets the step of the field fi to n, or the interval to n units if n is more than
+//the number of values in the field, as cron steps do not continue into the next period.
+//The tokens of the clause start at start.
+func (p *parser) setStep(fi, n int, unit time.Duration, start int) error {
+	if p.steps[fi] != 0 {
+		return fmt.Errorf("natural: %v is given more than once", sched.FieldName(fi))
+	}
+	if n > fieldSpans[fi] {
+		return p.setInterval(time.Duration(n)*unit, start)
+	}
+	p.steps[fi] = n
+	return nil
+}
+
+//setInterval sets the interval to d. The tokens of the clause start at start.
+func (p *parser) setInterval(d time.Duration, start int) error {
+	if p.interval != 0 {
+		return fmt.Errorf("natural: intervals are given more than once")
+	}
+	p.interval = d
+	p.intervalText = strings.Join(p.tokens[start:p.index], " ")
+	return nil
+}
+
+//parseWeekdays parses a list of weekdays and ranges of weekdays, or "weekday" or "weekend".
+func (p *parser) parseWeekdays() error {
+	switch {
+	case isWeekdaysWord(p.peek()):
+		p.next()
+		return p.set(sched.DowField, "1-5")
+	case isWeekendsWord(p.peek()):
+		p.next()
+		p.accept("day", "days")
+		return p.set(sched.DowField, "0,6")
+	}
+	items := []string{}
+	for {
+		first, ok := weekdayValue(p.peek())
+		if !ok {
+			return p.unexpected()
+		}
+		p.next()
+		item := fmt.Sprint(first)
+		if p.acceptRangeSeparator() {
+			last, ok := weekdayValue(p.peek())
+			if !ok {
+				return p.unexpected()
+			}
+			p.next()
+			item += sched.Hyphen + fmt.Sprint(last)
+		}
+		items = append(items, item)
+		if !p.acceptListSeparator(isWeekday) {
+			break
+		}
+	}
+	return p.set(sched.DowField, strings.Join(items, sched.Comma))
+}
+
+func isWeekdaysWord(token string) bool {
+	return token == "weekday" || token == "weekdays" || token == "workday" || token == "workdays"
+}
+
+func isWeekendsWord(token string) bool {
+	return token == "weekend" || token == "weekends"
+}
+
+func (p *parser) parseAt() error {
+	if p.accept("minute", "minutes", "second", "seconds") {
+		fi := sched.MinuteField
+		if strings.HasPrefix(p.tokens[p.index-1], "second") {
+			fi = sched.SecondField
+		}
+		values, err := p.parseNumbers(0, 59)
+		if err != nil {
+			return err
+		}
+		return p.set(fi, values)
+	}
+	if len(p.times) > 0 {
+		return fmt.Errorf("natural: times of day are given more than once")
+	}
+	for {
+		c, err := p.parseClock()
+		if err != nil {
+			return err
+		}
+		p.times = append(p.times, c)
+		if !p.acceptListSeparator(isClock) {
+			return nil
+		}
+	}
+}
+
+//parseNumbers parses a list of numbers and ranges of numbers in [min, max].
+func (p *parser) parseNumbers(min, max int) (string, error) {
+	items := []string{}
+	for {
+		first, err := p.parseNumber(min, max)
+		if err != nil {
+			return "", err
+		}
+		item := fmt.Sprint(first)
+		if p.acceptRangeSeparator() {
+			last, err := p.parseNumber(min, max)
+			if err != nil {
+				return "", err
+			}
+			item += sched.Hyphen + fmt.Sprint(last)
+		}
+		items = append(items, item)
+		if !p.acceptListSeparator(isNumber) {
+			return strings.Join(items, sched.Comma), nil
+		}
+	}
+}
+
+func (p *parser) parseNumber(min, max int) (int, error) {
+	value, err := strconv.Atoi(p.peek())
+	if err != nil {
+		return 0, p.unexpected()
+	}
+	if value < min || value > max {
+		return 0, fmt.Errorf("natural: %q must be in %v-%v", p.peek(), min, max)
+	}
+	p.next()
+	return value, nil
+}
+
+//parseClock parses a time of day, such as "9am", "9 am", "9:30", "17:00:30", or "noon".
+func (p *parser) parseClock() (clock, error) {
+	token := p.peek()
+	switch token {
+	case "noon", "midday":
+		p.next()
+		return clock{hour: 12}, nil
+	case "midnight":
+		p.next()
+		return clock{}, nil
+	}
+	match := clockRegexp.FindStringSubmatch(token)
+	if match == nil {
+		return clock{}, p.unexpected()
+	}
+	p.next()
+	c := clock{}
+	c.hour, _ = strconv.Atoi(match[1])
+	if match[2] != "" {
+		c.minute, _ = strconv.Atoi(match[2])
+	}
+	if match[3] != "" {
+		c.second, _ = strconv.Atoi(match[3])
+	}
+	meridiem := match[4]
+	if meridiem == "" && p.accept("am", "pm") {
+		meridiem = p.tokens[p.index-1]
+		token += " " + meridiem
+	}
+	c.hourOnly = match[2] == "" && meridiem == ""
+	if c.minute > 59 || c.second > 59 {
+		return c, fmt.Errorf("natural: %q is not a valid time of day", token)
+	}
+	if meridiem == "" {
+		if c.hour > 23 {
+			return c, fmt.Errorf("natural: %q is not a valid time of day", token)
+		}
+		return c, nil
+	}
+	if c.hour < 1 || c.hour > 12 {
+		return c, fmt.Errorf("natural: %q is not a valid time of day", token)
+	}
+	c.hour %= 12
+	if meridiem == "pm" {
+		c.hour += 12
+	}
+	return c, nil
+}
+
+//parseBetween parses a range of times of day, weekdays, or months.
+func (p *parser) parseBetween() error {
+	if first, ok := weekdayValue(p.peek()); ok {
+		p.next()
+		if !p.accept("and") && !p.acceptRangeSeparator() {
+			return p.unexpected()
+		}
+		last, ok := weekdayValue(p.peek())
+		if !ok {
+			return p.unexpected()
+		}
+		p.next()
+		return p.set(sched.DowField, fmt.Sprintf("%v-%v", first, last))
+	}
+	if first, ok := monthValue(p.peek()); ok {
+		p.next()
+		if !p.accept("and") && !p.acceptRangeSeparator() {
+			return p.unexpected()
+		}
+		last, ok := monthValue(p.peek())
+		if !ok {
+			return p.unexpected()
+		}
+		p.next()
+		return p.set(sched.MonthField, fmt.Sprintf("%v-%v", first, last))
+	}
+	first, err := p.parseClock()
+	if err != nil {
+		return err
+	}
+	if !p.accept("and") && !p.acceptRangeSeparator() {
+		return p.unexpected()
+	}
+	last, err := p.parseClock()
+	if err != nil {
+		return err
+	}
+	if first.hour == last.hour {
+		return fmt.Errorf("natural: a range of hours must not start and end in the same hour")
+	}
+	for _, c := range []clock{first, last} {
+		if c.minute != 0 || c.second != 0 {
+			return fmt.Errorf("natural: a range of times of day must start and end on the hour")
+		}
+	}
+	end := last.hour
+	if !last.hourOnly {
+		//the range ends at the start of its last hour, so the hour before it is the last one.
+		end = (end + 23) % 24
+	}
+	if end == first.hour {
+		return p.set(sched.HourField, fmt.Sprint(end))
+	}
+	return p.set(sched.HourField, fmt.Sprintf("%v-%v", first.hour, end))
+}
+
+//parseOn parses days of the month, such as "the 1st and 15th", "the last day", and
+//"day 10", or weekdays, such as "monday", "the last friday", and "the second tuesday".
+func (p *parser) parseOn() error {
+	if p.accept("day", "days") {
+		values, err := p.parseNumbers(sched.MinDom, sched.MaxDom)
+		if err != nil {
+			return err
+		}
+		p.acceptOfTheMonth()
+		return p.set(sched.DomField, values)
+	}
+	if !p.accept("the") {
+		return p.parseWeekdays()
+	}
+	if p.accept("last") {
+		var err error
+		switch token := p.next(); {
+		case token == "day":
+			err = p.set(sched.DomField, sched.Last)
+		case isWeekdaysWord(token):
+			err = p.set(sched.DomField, sched.Last+sched.Weekday)
+		default:
+			value, ok := weekdayValue(token)
+			if !ok {
+				p.index--
+				return p.unexpected()
+			}
+			err = p.set(sched.DowField, fmt.Sprint(value)+sched.Last)
+		}
+		p.acceptOfTheMonth()
+		return err
+	}
+	n, ok := ordinalValue(p.peek())
+	if !ok {
+		return p.unexpected()
+	}
+	p.next()
+	if value, ok := weekdayValue(p.peek()); ok {
+		p.next()
+		if n > sched.MaxHash {
+			return fmt.Errorf("natural: a month has at most %v of each weekday", sched.MaxHash)
+		}
+		p.acceptOfTheMonth()
+		return p.set(sched.DowField, fmt.Sprintf("%v%v%v", value, sched.Hash, n))
+	}
+	items := []string{fmt.Sprint(n)}
+	for p.acceptListSeparator(isOrdinal) {
+		p.accept("the")
+		n, ok := ordinalValue(p.peek())
+		if !ok {
+			return p.unexpected()
+		}
+		p.next()
+		items = append(items, fmt.Sprint(n))
+	}
+	p.accept("day", "days")
+	p.acceptOfTheMonth()
+	return p.set(sched.DomField, strings.Join(items, sched.Comma))
+}
+
+//acceptOfTheMonth accepts "of the month", "of each month", or "of every month".
+func (p *parser) acceptOfTheMonth() {
+	if p.index+2 >= len(p.tokens) || p.tokens[p.index] != "of" || p.tokens[p.index+2] != "month" {
+		return
+	}
+	switch p.tokens[p.index+1] {
+	case "the", "each", "every":
+		p.index += 3
+	}
+}
+
+//parseIn parses a list of months and ranges of months, or a year.
+func (p *parser) parseIn() error {
+	if value, err := strconv.Atoi(p.peek()); err == nil {
+		if value < 1000 {
+			return fmt.Errorf("natural: %q is not a year", p.peek())
+		}
+		p.next()
+		return p.set(sched.YearField, fmt.Sprint(value))
+	}
+	items := []string{}
+	for {
+		first, ok := monthValue(p.peek())
+		if !ok {
+			return p.unexpected()
+		}
+		p.next()
+		item := fmt.Sprint(first)
+		if p.acceptRangeSeparator() {
+			last, ok := monthValue(p.peek())
+			if !ok {
+				return p.unexpected()
+			}
+			p.next()
+			item += sched.Hyphen + fmt.Sprint(last)
+		}
+		items = append(items, item)
+		if !p.acceptListSeparator(isMonth) {
+			break
+		}
+	}
+	return p.set(sched.MonthField, strings.Join(items, sched.Comma))
+}
+
+func isWeekday(token string) bool {
+	_, ok := weekdayValue(token)
+	return ok
+}
+
+func isMonth(token string) bool {
+	_, ok := monthValue(token)
+	return ok
+}
+
+func isNumber(token string) bool {
+	_, err := strconv.Atoi(token)
+	return err == nil
+}
+
+//isOrdinal returns whether token is an ordinal or "the" before one.
+func isOrdinal(token string) bool {
+	_, ok := ordinalValue(token)
+	return ok || token == "the"
+}
+
+func isClock(token string) bool {
+	return token == "noon" || token == "midday" || token == "midnight" || clockRegexp.MatchString(token)
+}
+
+//weekdayValue returns the day of week value of the name, abbreviation, or plural of a weekday.
+func weekdayValue(token string) (int, bool) {
+	token = strings.TrimSuffix(token, "s")
+	for i, name := range weekdays {
+		if token == name || (len(token) >= 3 && strings.HasPrefix(name, token)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func monthValue(token string) (int, bool) {
+	for i, name := range months {
+		if token == name || (len(token) >= 3 && strings.HasPrefix(name, token)) {
+			return i + sched.MinMonth, true
+		}
+	}
+	return 0, false
+}
+
+//ordinalValue returns the value of an ordinal such as "1st", "22nd", or "third".
+func ordinalValue(token string) (int, bool) {
+	for i, word := range ordinalWords {
+		if i > 0 && token == word {
+			return i, true
+		}
+	}
+	match := ordinalRegexp.FindStringSubmatch(token)
+	if match == nil {
+		return 0, false
+	}
+	value, _ := strconv.Atoi(match[1])
+	return value, value >= sched.MinDom && value <= sched.MaxDom
+}
+
+//expression returns the cron expression of the parsed clauses.
+func (p *parser) expression() (string, error) {
+	if p.interval != 0 {
+		return p.intervalExpression()
+	}
+	fields := p.fields
+	for fi, n := range p.steps {
+		switch {
+		case n == 0:
+		case fields[fi] == "":
+			fields[fi] = sched.Asterisk + step(n)
+		case isRange(fields[fi]):
+			fields[fi] += step(n)
+		default:
+			return "", fmt.Errorf("natural: %v is given more than once", sched.FieldName(fi))
+		}
+	}
+	if fields[sched.DomField] == "" && fields[sched.DowField] == "" {
+		switch p.period {
+		case "week":
+			fields[sched.DowField] = fmt.Sprint(sched.MinDow)
+		case "month", "year":
+			fields[sched.DomField] = fmt.Sprint(sched.MinDom)
+		}
+	}
+	if p.period == "year" && fields[sched.MonthField] == "" {
+		fields[sched.MonthField] = fmt.Sprint(sched.MinMonth)
+	}
+	for fi := sched.DomField; fi < sched.FieldCount; fi++ {
+		if fields[fi] == "" {
+			fields[fi] = sched.Asterisk
+		}
+	}
+
+	if len(p.times) == 0 {
+		//the fields under the largest time of day field that is given are zero, and the
+		//fields over it are any value.
+		given := -1
+		for fi := sched.SecondField; fi <= sched.HourField; fi++ {
+			if fields[fi] != "" {
+				given = fi
+			}
+		}
+		for fi := sched.SecondField; fi <= sched.HourField; fi++ {
+			if fields[fi] == "" && (given < 0 || fi < given) {
+				fields[fi] = "0"
+			} else if fields[fi] == "" {
+				fields[fi] = sched.Asterisk
+			}
+		}
+		return strings.Join(fields[:], " "), nil
+	}
+
+	for fi := sched.SecondField; fi <= sched.HourField; fi++ {
+		if fields[fi] != "" {
+			return "", fmt.Errorf("natural: times of day cannot be given with the %v field", sched.FieldName(fi))
+		}
+	}
+	//times with the same minute and second share an expression.
+	hours := map[clock][]string{}
+	order := []clock{}
+	for _, t := range p.times {
+		key := clock{minute: t.minute, second: t.second}
+		if _, ok := hours[key]; !ok {
+			order = append(order, key)
+		}
+		hours[key] = append(hours[key], fmt.Sprint(t.hour))
+	}
+	expressions := make([]string, 0, len(order))
+	for _, key := range order {
+		fields[sched.SecondField], fields[sched.MinuteField] = fmt.Sprint(key.second), fmt.Sprint(key.minute)
+		fields[sched.HourField] = strings.Join(hours[key], sched.Comma)
+		expressions = append(expressions, strings.Join(fields[:], " "))
+	}
+	return strings.Join(expressions, sched.Semicolon+" "), nil
+}
+
+//step returns the step suffix of a field for every n values, which is empty for every value.
+func step(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return sched.Slash + fmt.Sprint(n)
+}
+
+//isRange returns whether value is a single range, such as "9-17".
+func isRange(value string) bool {
+	sides := strings.Split(value, sched.Hyphen)
+	return len(sides) == 2 && isNumber(sides[0]) && isNumber(sides[1])
+}
+
+//intervalExpression returns the Every expression of the interval. Only intervals of weeks
+//can be given with other clauses, which are a weekday and a time of day that anchor them.
+func (p *parser) intervalExpression() (string, error) {
+	weeks := p.interval%week == 0
+	others := p.period != "" || len(p.times) > 1 || (len(p.times) > 0 && !weeks)
+	for _, n := range p.steps {
+		others = others || n != 0
+	}
+	//intervals of weeks start on Sunday like "every week" does.
+	weekday := sched.MinDow
+	for fi, value := range p.fields {
+		if fi == sched.DowField && weeks && isNumber(value) {
+			weekday, _ = strconv.Atoi(value)
+			continue
+		}
+		others = others || value != ""
+	}
+	if others && weeks {
+		return "", fmt.Errorf("natural: %q can only be given with a single weekday and time of day", p.intervalText)
+	}
+	if others {
+		return "", fmt.Errorf("natural: %q cannot be given with other clauses", p.intervalText)
+	}
+	var offset time.Duration
+	if weeks {
+		offset = time.Duration((weekday-epochWeekday+7)%7) * day
+	}
+	for _, c := range p.times {
+		offset += time.Duration(c.hour)*time.Hour + time.Duration(c.minute)*time.Minute + time.Duration(c.second)*time.Second
+	}
+	return fmt.Sprintf("%v %v %v %v", sched.Every, p.interval, sched.Offset, offset), nil
+}
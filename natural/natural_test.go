@@ -0,0 +1,172 @@
+package natural
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		text   string
+		result string
+	}{
+		{"every second", "* * * * * * *"},
+		{"every minute", "0 * * * * * *"},
+		{"every hour", "0 0 * * * * *"},
+		{"every day", "0 0 0 * * * *"},
+		{"daily", "0 0 0 * * * *"},
+		{"every week", "0 0 0 * * 0 *"},
+		{"every month", "0 0 0 1 * * *"},
+		{"every year", "0 0 0 1 1 * *"},
+		{"annually", "0 0 0 1 1 * *"},
+		{"every 15 minutes", "0 */15 * * * * *"},
+		{"every 10 seconds", "*/10 * * * * * *"},
+		{"every other hour", "0 0 */2 * * * *"},
+		{"every 2 days at noon", "0 0 12 */2 * * *"},
+		{"every 3 months", "0 0 0 1 */3 * *"},
+		{"every weekday at 9am", "0 0 9 * * 1-5 *"},
+		{"Every weekday at 9:30 AM", "0 30 9 * * 1-5 *"},
+		{"every weekend at midnight", "0 0 0 * * 0,6 *"},
+		{"every 15 minutes between 9 and 17", "0 */15 9-17 * * * *"},
+		{"every minute from 9am to 5pm on weekdays", "0 * 9-16 * * 1-5 *"},
+		{"every 15 minutes between 9:00 and 17:00", "0 */15 9-16 * * * *"},
+		{"between 9am and 5pm", "0 0 9-16 * * * *"},
+		{"every minute between 9am and 10am", "0 * 9 * * * *"},
+		{"every minute from 10pm to midnight", "0 * 22-23 * * * *"},
+		{"every monday, wednesday, and friday at 17:00", "0 0 17 * * 1,3,5 *"},
+		{"mondays and thursdays at 6pm", "0 0 18 * * 1,4 *"},
+		{"every hour from monday to friday", "0 0 * * * 1-5 *"},
+		{"every hour at minute 30", "0 30 * * * * *"},
+		{"at second 15", "15 * * * * * *"},
+		{"at 9am and 5pm", "0 0 9,17 * * * *"},
+		{"at 9am and 5pm and on mondays", "0 0 9,17 * * 1 *"},
+		{"at 08:15:30", "30 15 8 * * * *"},
+		{"at 12am", "0 0 0 * * * *"},
+		{"at 12 pm", "0 0 12 * * * *"},
+		{"at 9:30 and 17:00", "0 30 9 * * * *; 0 0 17 * * * *"},
+		{"on the 1st and 15th at 8am", "0 0 8 1,15 * * *"},
+		{"on the 1st and the 15th of the month", "0 0 0 1,15 * * *"},
+		{"on day 10 of each month", "0 0 0 10 * * *"},
+		{"on the last day of the month at 23:59", "0 59 23 L * * *"},
+		{"on the last weekday of the month", "0 0 0 LW * * *"},
+		{"on the last friday of each month", "0 0 0 * * 5L *"},
+		{"on the second tuesday of the month at 10am", "0 0 10 * * 2#2 *"},
+		{"on the 3rd wed", "0 0 0 * * 3#3 *"},
+		{"at noon in january and july", "0 0 12 * 1,7 * *"},
+		{"daily at 6am in march through may", "0 0 6 * 3-5 * *"},
+		{"between jun and aug at 7am", "0 0 7 * 6-8 * *"},
+		{"every day at noon in 2027", "0 0 12 * * * 2027"},
+		{"every hour between 9 and 17 on weekdays", "0 0 9-17 * * 1-5 *"},
+		{"every 2 hours between 9 and 17", "0 0 9-17/2 * * * *"},
+		{"hourly from 10pm to 2am", "0 0 22-1 * * * *"},
+		{"every 5 minutes at minute 10-30", "0 10-30/5 * * * * *"},
+		{"every 60 minutes", "0 */60 * * * * *"},
+		{"every 12 months", "0 0 0 1 */12 * *"},
+		{"every 90 minutes", "@every 1h30m0s offset 0s"},
+		{"every 25 hours", "@every 25h0m0s offset 0s"},
+		{"every 40 days", "@every 960h0m0s offset 0s"},
+		{"every 2 weeks", "@every 336h0m0s offset 72h0m0s"},
+		{"every other week on monday at 9am", "@every 336h0m0s offset 105h0m0s"},
+		{"on thursday every 3 weeks at 17:30", "@every 504h0m0s offset 17h30m0s"},
+	}
+	for _, test := range tests {
+		result, err := Compile(test.text)
+		if err != nil || result != test.result {
+			t.Errorf("Compile(%q) = %q, %v WANT %q, <nil>", test.text, result, err, test.result)
+		}
+	}
+}
+
+func TestCompile_error(t *testing.T) {
+	tests := []struct {
+		text string
+		err  string
+	}{
+		{"", "natural: description cannot be empty"},
+		{"whenever", `natural: unexpected "whenever"`},
+		{"every", "natural: unexpected end of description"},
+		{"every 0 minutes", `natural: "0" must be positive`},
+		{"every 2 years", "natural: every 2 years is not supported"},
+		{"every 13 months", "natural: every 13 months is not supported"},
+		{"every 2 hours at minute 0 every 3 hours", "natural: hour is given more than once"},
+		{"every 2 minutes at minute 0,30", "natural: minute is given more than once"},
+		{"every 90 minutes between 9 and 17", `natural: "every 90 minutes" cannot be given with other clauses`},
+		{"every 25 hours at 9am", `natural: "every 25 hours" cannot be given with other clauses`},
+		{"every 2 weeks on weekdays", `natural: "every 2 weeks" can only be given with a single weekday and time of day`},
+		{"every 2 weeks at 9am and 5pm", `natural: "every 2 weeks" can only be given with a single weekday and time of day`},
+		{"every 90 minutes every 2 weeks", "natural: intervals are given more than once"},
+		{"at 25:00", `natural: "25:00" is not a valid time of day`},
+		{"at 13pm", `natural: "13pm" is not a valid time of day`},
+		{"at 0 am", `natural: "0 am" is not a valid time of day`},
+		{"at 9am at 5pm", "natural: times of day are given more than once"},
+		{"at 9am every hour", "natural: times of day cannot be given with the hour field"},
+		{"on monday on tuesday", "natural: day of week is given more than once"},
+		{"between 9 and 9:30", "natural: a range of hours must not start and end in the same hour"},
+		{"every 15 minutes between 9:30 and 17:00", "natural: a range of times of day must start and end on the hour"},
+		{"every minute between 9:00 and 10:15", "natural: a range of times of day must start and end on the hour"},
+		{"on the 6th monday", "natural: a month has at most 5 of each weekday"},
+		{"on day 32", `natural: "32" must be in 1-31`},
+		{"in 99", `natural: "99" is not a year`},
+		{"every month monthly", `natural: "every month" is given more than once`},
+	}
+	for _, test := range tests {
+		_, err := Compile(test.text)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Compile(%q) error = %v WANT %v", test.text, err, test.err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text       string
+		expression string
+	}{
+		{"every weekday at 9am", "0 0 9 * * 1-5 *"},
+		{"every 15 minutes between 9 and 17", "0 */15 9-17 * * * *"},
+		{"at 9:30 and 17:00", "{0 30 9 * * * *; 0 0 17 * * * *}"},
+		{"every 90 minutes", "@every 1h30m0s offset 0s"},
+	}
+	for _, test := range tests {
+		s, err := Parse(test.text)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", test.text, err)
+			continue
+		}
+		if result := s.Expression(); result != test.expression {
+			t.Errorf("Parse(%q).Expression() = %v WANT %v", test.text, result, test.expression)
+		}
+	}
+}
+
+func TestParse_NextTime(t *testing.T) {
+	tests := []struct {
+		text   string
+		from   time.Time
+		result time.Time
+	}{
+		{"every 90 minutes", date(2026, 3, 4, 23, 0), date(2026, 3, 5, 0, 0)},
+		{"every 90 minutes", date(2026, 3, 5, 0, 0), date(2026, 3, 5, 1, 30)},
+		{"every 25 hours", date(2026, 3, 4, 0, 0), date(2026, 3, 4, 16, 0)},
+		{"every 2 weeks", date(2026, 3, 4, 0, 0), date(2026, 3, 15, 0, 0)},
+		{"every 2 weeks", date(2026, 3, 15, 0, 0), date(2026, 3, 29, 0, 0)},
+		{"every other week on monday at 9am", date(2026, 3, 4, 0, 0), date(2026, 3, 16, 9, 0)},
+		{"every hour between 9 and 17 on weekdays", date(2026, 3, 6, 17, 0), date(2026, 3, 9, 9, 0)},
+		{"every 15 minutes between 9:00 and 17:00", date(2026, 3, 4, 16, 45), date(2026, 3, 5, 9, 0)},
+		{"every minute between 9am and 5pm", date(2026, 3, 4, 16, 59), date(2026, 3, 5, 9, 0)},
+	}
+	for _, test := range tests {
+		s, err := Parse(test.text)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", test.text, err)
+			continue
+		}
+		if result, ok := s.NextTime(test.from); !ok || !result.Equal(test.result) {
+			t.Errorf("Parse(%q).NextTime(%v) = %v, %v WANT %v, true", test.text, test.from, result, ok, test.result)
+		}
+	}
+}
+
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
@@ -0,0 +1,215 @@
+//Package reload keeps the jobs of a cron.Cron in sync with a jobs file while it is edited.
+//
+//A jobs file declares each job as a cron.JobSpec, in a list or in the "jobs" list of an
+//object:
+//
+//	{"jobs": [
+//		{"id": "backup", "schedule": "0 0 2 * * *", "timezone": "America/New_York", "jitter": "5m", "data": "/usr/bin/backup"},
+//		{"id": "report", "schedule": "@weekly", "enabled": false}
+//	]}
+//
+//Fields that a JobSpec does not have are errors. Each time the file is loaded, it is applied
+//to the Cron with cron.Cron.Apply, so jobs without an ID are never changed.
+//
+//Jobs files are JSON unless a Watcher is given another DecodeFunc, such as one from package
+//jobfile for YAML and TOML. Package reload only depends on the standard library.
+package reload
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogolfing/cron"
+)
+
+//DefaultInterval is how often a Watcher checks its file if its Interval is zero.
+const DefaultInterval = time.Second
+
+//DecodeFunc decodes the JobSpecs in the contents of a jobs file.
+type DecodeFunc func(data []byte) ([]*cron.JobSpec, error)
+
+//jobsFile is a jobs file that is an object.
+type jobsFile struct {
+	Jobs []*cron.JobSpec `json:"jobs"`
+}
+
+//DecodeJSON decodes a JSON array of JobSpecs, or an object with a "jobs" array.
+func DecodeJSON(data []byte) ([]*cron.JobSpec, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		result := []*cron.JobSpec{}
+		return result, decoder.Decode(&result)
+	}
+	file := &jobsFile{}
+	return file.Jobs, decoder.Decode(file)
+}
+
+//Error is the error for a jobs file with invalid JobSpecs. No changes are made to the Cron
+//when there is an Error.
+type Error struct {
+	Path string
+
+	//Errs are the problems with the JobSpecs, in the order they are declared.
+	Errs []error
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("reload: %v: %v", e.Path, strings.Join(messages, "; "))
+}
+
+//Unwrap returns the Errs of e so that errors.As can find a *sched.ParseError in them.
+func (e *Error) Unwrap() []error {
+	return e.Errs
+}
+
+//Watcher loads a jobs file into a Cron each time the file is modified.
+type Watcher struct {
+	//Path is the jobs file.
+	Path string
+
+	//Cron is the Cron that the jobs are loaded into.
+	Cron *cron.Cron
+
+	//Interval is how often the modification time of the file is checked.
+	//If zero, DefaultInterval is used.
+	Interval time.Duration
+
+	//Decode decodes the file. If nil, DecodeJSON is used.
+	Decode DecodeFunc
+
+	//OnLoad is called from the watching goroutine with the result of each load after the
+	//first, which Start returns. It may be nil.
+	OnLoad func(*cron.ApplyResult, error)
+
+	lock sync.Mutex
+
+	//modTime and size are the state of the file when it was last loaded.
+	modTime time.Time
+	size    int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewWatcher(path string, c *cron.Cron) *Watcher {
+	return &Watcher{
+		Path: path,
+		Cron: c,
+	}
+}
+
+//Start loads the file and then checks it every Interval until Stop is called.
+//The file is watched even if the first load fails.
+func (w *Watcher) Start() (*cron.ApplyResult, error) {
+	w.lock.Lock()
+	if w.stop != nil {
+		w.lock.Unlock()
+		return &cron.ApplyResult{}, nil
+	}
+	w.stop, w.done = make(chan struct{}), make(chan struct{})
+	stop, done := w.stop, w.done
+	w.lock.Unlock()
+
+	result, err := w.Load()
+	go w.watch(stop, done)
+	return result, err
+}
+
+//Stop stops checking the file and waits for a load in progress to finish.
+func (w *Watcher) Stop() {
+	w.lock.Lock()
+	if w.stop == nil {
+		w.lock.Unlock()
+		return
+	}
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.lock.Unlock()
+
+	close(stop)
+	<-done
+}
+
+func (w *Watcher) watch(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(w.interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if loaded, result, err := w.check(); loaded && w.OnLoad != nil {
+				w.OnLoad(result, err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (w *Watcher) interval() time.Duration {
+	if w.Interval <= 0 {
+		return DefaultInterval
+	}
+	return w.Interval
+}
+
+//check loads the file if it has been modified since it was last loaded, and returns whether
+//it did.
+func (w *Watcher) check() (bool, *cron.ApplyResult, error) {
+	info, err := os.Stat(w.Path)
+	if err != nil {
+		return false, nil, err
+	}
+	w.lock.Lock()
+	modified := !info.ModTime().Equal(w.modTime) || info.Size() != w.size
+	w.lock.Unlock()
+	if !modified {
+		return false, nil, nil
+	}
+	result, err := w.Load()
+	return true, result, err
+}
+
+//Load reads the file now and applies it to the Cron.
+//If the file cannot be read or decoded, or any of its JobSpecs are invalid, the Cron is not
+//changed and the error is returned. The error is an *Error for invalid JobSpecs.
+func (w *Watcher) Load() (*cron.ApplyResult, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	info, err := os.Stat(w.Path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return nil, err
+	}
+	//the file is not loaded again until it changes, even if it is invalid.
+	w.modTime, w.size = info.ModTime(), info.Size()
+
+	decode := w.Decode
+	if decode == nil {
+		decode = DecodeJSON
+	}
+	specs, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("reload: %v: %w", w.Path, err)
+	}
+	result, err := w.Cron.Apply(specs)
+	var specErr *cron.SpecError
+	if errors.As(err, &specErr) {
+		return nil, &Error{Path: w.Path, Errs: specErr.Errs}
+	}
+	return result, err
+}
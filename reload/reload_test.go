@@ -0,0 +1,249 @@
+package reload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gogolfing/cron"
+	"github.com/gogolfing/cron/sched"
+)
+
+func writeJobs(t *testing.T, path, contents string, modTime time.Time) {
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//jobsByID returns the expressions and data of the jobs of c by ID.
+func jobsByID(c *cron.Cron) map[string][2]interface{} {
+	result := map[string][2]interface{}{}
+	for _, job := range c.Jobs() {
+		result[job.ID] = [2]interface{}{job.Expression(), job.Data}
+	}
+	return result
+}
+
+func TestWatcher_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	c := cron.NewCron(time.UTC)
+	manual := c.AddSchedule(sched.MustParse("@hourly"), "manual")
+	c.AddJob(&cron.Job{ID: "stale", Schedule: sched.MustParse("@daily")})
+	w := NewWatcher(path, c)
+
+	writeJobs(t, path, `[
+		{"id": "backup", "schedule": "0 0 2 * * *", "data": "backup"},
+		{"id": "report", "schedule": "@weekly"}
+	]`, time.Unix(1000, 0))
+	result, err := w.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	sort.Strings(result.Added)
+	want := &cron.ApplyResult{Added: []string{"backup", "report"}, Removed: []string{"stale"}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Load() = %+v WANT %+v", result, want)
+	}
+
+	writeJobs(t, path, `[
+		{"id": "backup", "schedule": "0 0 3 * * *", "data": "backup"},
+		{"id": "report", "schedule": "@weekly", "data": "pdf"},
+		{"id": "cleanup", "schedule": "@daily"}
+	]`, time.Unix(2000, 0))
+	result, err = w.Load()
+	want = &cron.ApplyResult{Added: []string{"cleanup"}, Changed: []string{"backup"}, Replaced: []string{"report"}}
+	if err != nil || !reflect.DeepEqual(result, want) {
+		t.Errorf("Load() = %+v, %v WANT %+v, <nil>", result, err, want)
+	}
+	wantJobs := map[string][2]interface{}{
+		"":        {manual.Expression(), "manual"},
+		"backup":  {"0 0 3 * * * *", "backup"},
+		"report":  {sched.MustParse("@weekly").Expression(), "pdf"},
+		"cleanup": {sched.MustParse("@daily").Expression(), nil},
+	}
+	if jobs := jobsByID(c); !reflect.DeepEqual(jobs, wantJobs) {
+		t.Errorf("Cron jobs = %v WANT %v", jobs, wantJobs)
+	}
+
+	if result, err = w.Load(); err != nil || !result.IsEmpty() {
+		t.Errorf("Load() unchanged = %+v, %v WANT no changes", result, err)
+	}
+}
+
+func TestWatcher_Load_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	c := cron.NewCron(time.UTC)
+	c.AddJob(&cron.Job{ID: "backup", Schedule: sched.MustParse("@daily")})
+	before := jobsByID(c)
+	w := NewWatcher(path, c)
+
+	writeJobs(t, path, `[
+		{"id": "backup", "schedule": "0 0 3 * * *"},
+		{"id": "report", "schedule": "@daily", "timezone": "Nowhere/Nothing"},
+		{"schedule": "@daily"},
+		{"id": "backup", "schedule": "@daily"}
+	]`, time.Unix(1000, 0))
+	_, err := w.Load()
+	var loadErr *Error
+	if !errors.As(err, &loadErr) || len(loadErr.Errs) != 3 {
+		t.Fatalf("Load() error = %v WANT *Error with 3 errors", err)
+	}
+	if jobs := jobsByID(c); !reflect.DeepEqual(jobs, before) {
+		t.Errorf("Cron jobs = %v WANT unchanged %v", jobs, before)
+	}
+
+	writeJobs(t, path, `[{"id": "report", "schedule": "0 70 * * * *"}]`, time.Unix(2000, 0))
+	_, err = w.Load()
+	var pe *sched.ParseError
+	if !errors.As(err, &pe) || pe.FieldName != "minute" {
+		t.Errorf("errors.As(Load(), *sched.ParseError) = %v WANT a minute field error", pe)
+	}
+
+	for i, contents := range []string{`{"id": "backup"}`, `[{"id": "backup", "schedule": "@daily", "color": "red"}]`} {
+		writeJobs(t, path, contents, time.Unix(int64(3000+i), 0))
+		if _, err := w.Load(); err == nil {
+			t.Errorf("Load(%v) error = <nil> WANT a decode error", contents)
+		}
+	}
+	if jobs := jobsByID(c); !reflect.DeepEqual(jobs, before) {
+		t.Errorf("Cron jobs = %v WANT unchanged %v", jobs, before)
+	}
+}
+
+func TestWatcher_check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	w := NewWatcher(path, cron.NewCron(time.UTC))
+	if loaded, _, err := w.check(); loaded || err == nil {
+		t.Errorf("check() missing file = %v, %v WANT false, error", loaded, err)
+	}
+	writeJobs(t, path, `[{"id": "a", "schedule": "@daily"}]`, time.Unix(1000, 0))
+	if loaded, result, err := w.check(); !loaded || err != nil || len(result.Added) != 1 {
+		t.Errorf("check() = %v, %+v, %v WANT true, one added, <nil>", loaded, result, err)
+	}
+	if loaded, _, _ := w.check(); loaded {
+		t.Errorf("check() unmodified = true WANT false")
+	}
+	writeJobs(t, path, `[{"id": "a", "schedule": "@hourly"}]`, time.Unix(2000, 0))
+	if loaded, result, err := w.check(); !loaded || err != nil || len(result.Changed) != 1 {
+		t.Errorf("check() = %v, %+v, %v WANT true, one changed, <nil>", loaded, result, err)
+	}
+}
+
+func TestWatcher_StartStop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	writeJobs(t, path, `[{"id": "a", "schedule": "@daily"}]`, time.Unix(1000, 0))
+	c := cron.NewCron(time.UTC)
+	w := NewWatcher(path, c)
+	w.Interval = time.Millisecond
+	loads := make(chan *cron.ApplyResult, 1)
+	w.OnLoad = func(result *cron.ApplyResult, err error) {
+		if err == nil {
+			loads <- result
+		}
+	}
+	if result, err := w.Start(); err != nil || len(result.Added) != 1 {
+		t.Fatalf("Start() = %+v, %v WANT one added", result, err)
+	}
+	writeJobs(t, path, `[{"id": "b", "schedule": "@daily"}]`, time.Unix(2000, 0))
+	select {
+	case result := <-loads:
+		if !reflect.DeepEqual(result, &cron.ApplyResult{Added: []string{"b"}, Removed: []string{"a"}}) {
+			t.Errorf("OnLoad() result = %+v WANT b added and a removed", result)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("OnLoad() was not called")
+	}
+	w.Stop()
+	w.Stop()
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []string{
+		`[{"id": "backup", "schedule": "@daily", "jitter": "5m", "data": {"path": "/usr/bin/backup"}}]`,
+		`{"jobs": [{"id": "backup", "schedule": "@daily", "jitter": "5m", "data": {"path": "/usr/bin/backup"}}]}`,
+	}
+	for _, contents := range tests {
+		specs, err := DecodeJSON([]byte(contents))
+		if err != nil || len(specs) != 1 {
+			t.Errorf("DecodeJSON(%v) = %v, %v WANT one spec", contents, specs, err)
+			continue
+		}
+		spec := specs[0]
+		data := map[string]interface{}{"path": "/usr/bin/backup"}
+		if spec.ID != "backup" || spec.Schedule != "@daily" || spec.Jitter != cron.Duration(5*time.Minute) || !reflect.DeepEqual(spec.Data, data) {
+			t.Errorf("DecodeJSON(%v) = %+v WANT backup @daily with 5m jitter and %v", contents, spec, data)
+		}
+	}
+}
+
+func TestDecodeJSON_unknownFields(t *testing.T) {
+	tests := []string{
+		`[{"id": "backup", "schedule": "@daily", "color": "red"}]`,
+		`{"job": [{"id": "backup"}]}`,
+	}
+	for _, contents := range tests {
+		if _, err := DecodeJSON([]byte(contents)); err == nil {
+			t.Errorf("DecodeJSON(%v) error = <nil> WANT an error", contents)
+		}
+	}
+}
+
+func TestWatcher_literal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	writeJobs(t, path, `[{"id": "a", "schedule": "@daily"}]`, time.Unix(1000, 0))
+	w := &Watcher{Path: path, Cron: cron.NewCron(time.UTC), Interval: time.Millisecond}
+	if result, err := w.Start(); err != nil || len(result.Added) != 1 {
+		t.Errorf("Start() = %+v, %v WANT one added", result, err)
+	}
+	w.Stop()
+}
+
+func TestWatcher_Load_specs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	c := cron.NewCron(time.UTC)
+	w := NewWatcher(path, c)
+	writeJobs(t, path, `[
+		{"id": "report", "schedule": "0 0 9 * * *", "timezone": "America/New_York", "jitter": "10m", "overlap": "skip"},
+		{"id": "warm", "schedule": "@reboot", "jitter": "1m"}
+	]`, time.Unix(1000, 0))
+	if _, err := w.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for _, job := range c.Jobs() {
+		if job.Spec == nil || job.Spec.ID != job.ID {
+			t.Errorf("Job(%v).Spec = %v WANT its spec", job.ID, job.Spec)
+		}
+		s, ok := job.Schedule.(*sched.SplaySchedule)
+		if !ok {
+			t.Errorf("Job(%v).Schedule = %v WANT a splayed schedule", job.ID, job.Schedule)
+			continue
+		}
+		if _, ok := s.Schedule.(*sched.LocationSchedule); job.ID == "report" && !ok {
+			t.Errorf("Job(%v).Schedule = %v WANT report in a time zone", job.ID, s)
+		}
+		if job.ID == "warm" && !sched.IsReboot(s) {
+			t.Errorf("Job(%v).Schedule = %v WANT a reboot schedule", job.ID, s)
+		}
+	}
+
+	writeJobs(t, path, `[
+		{"id": "report", "schedule": "0 0 9 * * *", "overlap": "queue", "enabled": false},
+		{"id": "warm", "schedule": "@reboot", "misfire": "never"}
+	]`, time.Unix(2000, 0))
+	if _, err := w.Load(); err == nil {
+		t.Errorf("Load() unknown misfire error = <nil> WANT an error")
+	}
+	writeJobs(t, path, `[{"id": "report", "schedule": "0 0 9 * * *", "enabled": false}]`, time.Unix(3000, 0))
+	result, err := w.Load()
+	want := &cron.ApplyResult{Removed: []string{"report", "warm"}}
+	if err != nil || !reflect.DeepEqual(result, want) || len(c.Jobs()) != 0 {
+		t.Errorf("Load() = %+v, %v WANT %+v and no jobs", result, err, want)
+	}
+}
@@ -0,0 +1,361 @@
+package rrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+var frequencyNames = [...]string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func (f Frequency) String() string {
+	if f < Secondly || f > Yearly {
+		return fmt.Sprintf("Frequency(%v)", int(f))
+	}
+	return frequencyNames[f]
+}
+
+//Parse parses text made of a DTSTART line, an RRULE line, and any number of EXDATE lines,
+//such as:
+//
+//	DTSTART;TZID=America/New_York:20260105T090000
+//	RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1
+//	EXDATE;TZID=America/New_York:20260130T090000
+//
+//DTSTART values without a TZID parameter or "Z" suffix are in time.Local.
+func Parse(text string) (*Schedule, error) {
+	properties, err := splitProperties(text)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schedule{}
+	var rrule *property
+	hasStart := false
+	for _, p := range properties {
+		switch p.name {
+		case "DTSTART":
+			if s.DTStart, _, err = p.parseTime(time.Local); err != nil {
+				return nil, err
+			}
+			hasStart = true
+		case "RRULE":
+			if rrule != nil {
+				return nil, fmt.Errorf("rrule: only one RRULE is supported")
+			}
+			rrule = p
+		case "EXDATE":
+		default:
+			return nil, fmt.Errorf("rrule: property %q is not supported", p.name)
+		}
+	}
+	if !hasStart {
+		return nil, fmt.Errorf("rrule: DTSTART is required")
+	}
+	if rrule == nil {
+		return nil, fmt.Errorf("rrule: RRULE is required")
+	}
+	if s.Rule, err = parseRule(rrule.value, s.DTStart.Location()); err != nil {
+		return nil, err
+	}
+	for _, p := range properties {
+		if p.name != "EXDATE" {
+			continue
+		}
+		for _, value := range strings.Split(p.value, ",") {
+			exdate, _, err := (&property{p.name, p.params, value}).parseTime(s.DTStart.Location())
+			if err != nil {
+				return nil, err
+			}
+			s.ExDates = append(s.ExDates, exdate)
+		}
+	}
+	return s, nil
+}
+
+//ParseRule parses the value of an RRULE, such as "FREQ=WEEKLY;BYDAY=MO,WE".
+//UNTIL values without a "Z" suffix are in UTC.
+func ParseRule(value string) (Rule, error) {
+	return parseRule(value, time.UTC)
+}
+
+//parseRule parses value with UNTIL values without a "Z" suffix in loc.
+//An UNTIL date includes all of that day.
+func parseRule(value string, loc *time.Location) (Rule, error) {
+	r := Rule{WeekStart: time.Monday}
+	hasFreq := false
+	for _, part := range strings.Split(strings.TrimPrefix(value, "RRULE:"), ";") {
+		keyValue := strings.SplitN(part, "=", 2)
+		if len(keyValue) != 2 {
+			return r, fmt.Errorf("rrule: rule part %q must be in the form NAME=VALUE", part)
+		}
+		name, v := strings.ToUpper(keyValue[0]), keyValue[1]
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq, err = parseFrequency(v)
+			hasFreq = true
+		case "INTERVAL":
+			r.Interval, err = parsePositive(v)
+		case "COUNT":
+			r.Count, err = parsePositive(v)
+		case "UNTIL":
+			var isDate bool
+			r.Until, isDate, err = (&property{name: name}).withValue(v).parseTime(loc)
+			if isDate {
+				r.Until = r.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "BYSECOND":
+			r.BySecond, err = parseInts(v, 0, 59, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseInts(v, 0, 59, false)
+		case "BYHOUR":
+			r.ByHour, err = parseInts(v, 0, 23, false)
+		case "BYDAY":
+			r.ByDay, err = parseWeekdayNums(v)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(v, 1, 31, true)
+		case "BYMONTH":
+			r.ByMonth, err = parseInts(v, 1, 12, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(v, 1, 366, true)
+		case "WKST":
+			r.WeekStart, err = parseWeekday(v)
+		default:
+			err = fmt.Errorf("is not supported")
+		}
+		if err != nil {
+			return r, fmt.Errorf("rrule: %v %v", name, err.Error())
+		}
+	}
+	if !hasFreq {
+		return r, fmt.Errorf("rrule: FREQ is required")
+	}
+	return r, r.validate()
+}
+
+//validate returns an error for combinations of rule parts that RFC 5545 does not allow.
+func (r Rule) validate() error {
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("rrule: COUNT and UNTIL must not both be present")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("rrule: BYMONTHDAY must not be present with FREQ=WEEKLY")
+	}
+	if r.Freq != Monthly && r.Freq != Yearly {
+		for _, wn := range r.ByDay {
+			if wn.N != 0 {
+				return fmt.Errorf("rrule: BYDAY must not have numeric values with FREQ=%v", r.Freq)
+			}
+		}
+	}
+	hasBy := len(r.BySecond) > 0 || len(r.ByMinute) > 0 || len(r.ByHour) > 0 || len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 || len(r.ByMonth) > 0
+	if len(r.BySetPos) > 0 && !hasBy {
+		return fmt.Errorf("rrule: BYSETPOS must be used with another BYxxx rule part")
+	}
+	return nil
+}
+
+func parseFrequency(value string) (Frequency, error) {
+	for i, name := range frequencyNames {
+		if strings.EqualFold(value, name) {
+			return Frequency(i), nil
+		}
+	}
+	return Secondly, fmt.Errorf("value %q is not a frequency", value)
+}
+
+func parsePositive(value string) (int, error) {
+	result, err := strconv.Atoi(value)
+	if err != nil || result <= 0 {
+		return 0, fmt.Errorf("value %q must be a positive integer", value)
+	}
+	return result, nil
+}
+
+//parseInts parses the comma separated integers in value in [min, max], or in [-max, -min]
+//too if negative.
+func parseInts(value string, min, max int, negative bool) ([]int, error) {
+	result := []int{}
+	for _, part := range strings.Split(value, ",") {
+		v, err := strconv.Atoi(part)
+		abs := v
+		if negative && v < 0 {
+			abs = -v
+		}
+		if err != nil || abs < min || abs > max {
+			return nil, fmt.Errorf("value %q is invalid", part)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func parseWeekdayNums(value string) ([]WeekdayNum, error) {
+	result := []WeekdayNum{}
+	for _, part := range strings.Split(value, ",") {
+		if len(part) < 2 {
+			return nil, fmt.Errorf("value %q is invalid", part)
+		}
+		weekday, err := parseWeekday(part[len(part)-2:])
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		if number := part[:len(part)-2]; number != "" {
+			n, err = strconv.Atoi(number)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("value %q is invalid", part)
+			}
+		}
+		result = append(result, WeekdayNum{weekday, n})
+	}
+	return result, nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for i, name := range weekdayNames {
+		if strings.EqualFold(value, name) {
+			return time.Weekday(i), nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("value %q is not a weekday", value)
+}
+
+//property is a content line, such as "DTSTART;TZID=America/New_York:20260105T090000".
+type property struct {
+	name   string
+	params []string
+	value  string
+}
+
+//splitProperties returns the unfolded, non-empty lines of text as properties.
+//A line without a name, such as "FREQ=DAILY", is an RRULE.
+func splitProperties(text string) ([]*property, error) {
+	text = strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "").Replace(text)
+	result := []*property{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		colonIndex := strings.Index(line, ":")
+		if colonIndex < 0 {
+			if !strings.Contains(line, "=") {
+				return nil, fmt.Errorf("rrule: line %q must be in the form NAME:VALUE", line)
+			}
+			result = append(result, &property{name: "RRULE", value: line})
+			continue
+		}
+		parts := strings.Split(line[:colonIndex], ";")
+		result = append(result, &property{strings.ToUpper(parts[0]), parts[1:], line[colonIndex+1:]})
+	}
+	return result, nil
+}
+
+func (p *property) withValue(value string) *property {
+	return &property{p.name, p.params, value}
+}
+
+//parseTime parses the value of p as a DATE or DATE-TIME and returns whether it was a DATE.
+//Values without a TZID parameter or "Z" suffix are in loc.
+func (p *property) parseTime(loc *time.Location) (time.Time, bool, error) {
+	for _, param := range p.params {
+		keyValue := strings.SplitN(param, "=", 2)
+		if len(keyValue) == 2 && strings.EqualFold(keyValue[0], "TZID") {
+			var err error
+			if loc, err = time.LoadLocation(strings.Trim(keyValue[1], `"`)); err != nil {
+				return time.Time{}, false, fmt.Errorf("rrule: %v %v", p.name, err.Error())
+			}
+		}
+	}
+	value := p.value
+	if strings.HasSuffix(value, "Z") {
+		value, loc = strings.TrimSuffix(value, "Z"), time.UTC
+	}
+	if t, err := time.ParseInLocation(dateTimeLayout, value, loc); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.ParseInLocation(dateLayout, value, loc); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("rrule: %v value %q is not a valid date or date-time", p.name, p.value)
+}
+
+//String returns r as the value of an RRULE.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%v", r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%v", r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(dateTimeLayout)+"Z")
+	}
+	parts = appendInts(parts, "BYSECOND", r.BySecond)
+	parts = appendInts(parts, "BYMINUTE", r.ByMinute)
+	parts = appendInts(parts, "BYHOUR", r.ByHour)
+	if len(r.ByDay) > 0 {
+		days := []string{}
+		for _, wn := range r.ByDay {
+			day := weekdayNames[wn.Weekday]
+			if wn.N != 0 {
+				day = fmt.Sprint(wn.N) + day
+			}
+			days = append(days, day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	parts = appendInts(parts, "BYMONTHDAY", r.ByMonthDay)
+	parts = appendInts(parts, "BYMONTH", r.ByMonth)
+	parts = appendInts(parts, "BYSETPOS", r.BySetPos)
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+func appendInts(parts []string, name string, values []int) []string {
+	if len(values) == 0 {
+		return parts
+	}
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		strs = append(strs, fmt.Sprint(v))
+	}
+	return append(parts, name+"="+strings.Join(strs, ","))
+}
+
+func (s *Schedule) String() string {
+	return fmt.Sprintf("rrule.Schedule(%q)", s.Expression())
+}
+
+//Expression returns s in the form that Parse accepts, which has multiple lines.
+//It is not an expression that sched.Parse accepts, so s cannot be stored as a sched.Expr.
+func (s *Schedule) Expression() string {
+	lines := []string{"DTSTART" + formatTime(s.DTStart), "RRULE:" + s.Rule.String()}
+	for _, exdate := range s.ExDates {
+		lines = append(lines, "EXDATE"+formatTime(exdate.In(s.DTStart.Location())))
+	}
+	return strings.Join(lines, "\n")
+}
+
+//formatTime returns the parameters and value of a DATE-TIME property for t.
+func formatTime(t time.Time) string {
+	switch t.Location() {
+	case time.UTC:
+		return ":" + t.Format(dateTimeLayout) + "Z"
+	case time.Local:
+		return ":" + t.Format(dateTimeLayout)
+	}
+	return ";TZID=" + t.Location().String() + ":" + t.Format(dateTimeLayout)
+}
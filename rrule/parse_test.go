@@ -0,0 +1,160 @@
+package rrule
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogolfing/cron/sched"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		value  string
+		result Rule
+	}{
+		{"FREQ=DAILY", Rule{Freq: Daily, WeekStart: time.Monday}},
+		{"RRULE:FREQ=weekly;INTERVAL=2;BYDAY=MO,WE;WKST=SU", Rule{
+			Freq: Weekly, Interval: 2, ByDay: []WeekdayNum{{time.Monday, 0}, {time.Wednesday, 0}}, WeekStart: time.Sunday,
+		}},
+		{"FREQ=MONTHLY;BYDAY=2TU,-1FR;COUNT=10", Rule{
+			Freq: Monthly, Count: 10, ByDay: []WeekdayNum{{time.Tuesday, 2}, {time.Friday, -1}}, WeekStart: time.Monday,
+		}},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;BYSETPOS=-1", Rule{
+			Freq: Monthly, ByMonthDay: []int{1, -1}, BySetPos: []int{-1}, WeekStart: time.Monday,
+		}},
+		{"FREQ=YEARLY;BYMONTH=1,7;BYHOUR=9;BYMINUTE=30;BYSECOND=15;UNTIL=20301231T235959Z", Rule{
+			Freq: Yearly, Until: utc(2030, time.December, 31, 23, 59, 59), BySecond: []int{15}, ByMinute: []int{30},
+			ByHour: []int{9}, ByMonth: []int{1, 7}, WeekStart: time.Monday,
+		}},
+		{"FREQ=DAILY;UNTIL=20301231", Rule{
+			Freq: Daily, Until: utc(2031, time.January, 1, 0, 0, 0).Add(-time.Nanosecond), WeekStart: time.Monday,
+		}},
+	}
+	for _, test := range tests {
+		result, err := ParseRule(test.value)
+		if err != nil || !reflect.DeepEqual(result, test.result) {
+			t.Errorf("ParseRule(%q) = %v, %v WANT %v, <nil>", test.value, result, err, test.result)
+		}
+	}
+}
+
+func TestParseRule_error(t *testing.T) {
+	tests := []struct {
+		value string
+		err   string
+	}{
+		{"", `rrule: rule part "" must be in the form NAME=VALUE`},
+		{"INTERVAL=2", "rrule: FREQ is required"},
+		{"FREQ=FORTNIGHTLY", `rrule: FREQ value "FORTNIGHTLY" is not a frequency`},
+		{"FREQ=DAILY;INTERVAL=0", `rrule: INTERVAL value "0" must be a positive integer`},
+		{"FREQ=DAILY;BYHOUR=24", `rrule: BYHOUR value "24" is invalid`},
+		{"FREQ=MONTHLY;BYMONTHDAY=0", `rrule: BYMONTHDAY value "0" is invalid`},
+		{"FREQ=MONTHLY;BYMONTHDAY=-32", `rrule: BYMONTHDAY value "-32" is invalid`},
+		{"FREQ=MONTHLY;BYDAY=0MO", `rrule: BYDAY value "0MO" is invalid`},
+		{"FREQ=MONTHLY;BYDAY=XX", `rrule: BYDAY value "XX" is not a weekday`},
+		{"FREQ=YEARLY;BYYEARDAY=100", "rrule: BYYEARDAY is not supported"},
+		{"FREQ=DAILY;COUNT=2;UNTIL=20300101", "rrule: COUNT and UNTIL must not both be present"},
+		{"FREQ=WEEKLY;BYMONTHDAY=1", "rrule: BYMONTHDAY must not be present with FREQ=WEEKLY"},
+		{"FREQ=WEEKLY;BYDAY=1MO", "rrule: BYDAY must not have numeric values with FREQ=WEEKLY"},
+		{"FREQ=MONTHLY;BYSETPOS=1", "rrule: BYSETPOS must be used with another BYxxx rule part"},
+	}
+	for _, test := range tests {
+		_, err := ParseRule(test.value)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("ParseRule(%q) error = %v WANT %v", test.value, err, test.err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := "DTSTART;TZID=America/New_York:20260105T090000\r\n" +
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,\r\n WE;UNTIL=20260131\r\n" +
+		"EXDATE;TZID=America/New_York:20260107T090000,20260112T090000\r\n"
+	s, err := Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Schedule{
+		Rule: Rule{
+			Freq:      Weekly,
+			Until:     time.Date(2026, time.February, 1, 0, 0, 0, 0, newYork).Add(-time.Nanosecond),
+			ByDay:     []WeekdayNum{{time.Monday, 0}, {time.Wednesday, 0}},
+			WeekStart: time.Monday,
+		},
+		DTStart: time.Date(2026, time.January, 5, 9, 0, 0, 0, newYork),
+		ExDates: []time.Time{time.Date(2026, time.January, 7, 9, 0, 0, 0, newYork), time.Date(2026, time.January, 12, 9, 0, 0, 0, newYork)},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Parse(%q) = %v WANT %v", text, s, want)
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	tests := []struct {
+		text string
+		err  string
+	}{
+		{"RRULE:FREQ=DAILY", "rrule: DTSTART is required"},
+		{"DTSTART:20260101T000000Z", "rrule: RRULE is required"},
+		{"DTSTART:2026\nRRULE:FREQ=DAILY", `rrule: DTSTART value "2026" is not a valid date or date-time`},
+		{"DTSTART;TZID=Nowhere/Special:20260101T000000\nRRULE:FREQ=DAILY", "rrule: DTSTART unknown time zone Nowhere/Special"},
+		{"DTSTART:20260101T000000Z\nRRULE:FREQ=DAILY\nRRULE:FREQ=WEEKLY", "rrule: only one RRULE is supported"},
+		{"DTSTART:20260101T000000Z\nRRULE:FREQ=DAILY\nRDATE:20260105T000000Z", `rrule: property "RDATE" is not supported`},
+		{"DTSTART:20260101T000000Z\nnonsense", `rrule: line "nonsense" must be in the form NAME:VALUE`},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.text)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Parse(%q) error = %v WANT %v", test.text, err, test.err)
+		}
+	}
+}
+
+func TestSchedule_Expression(t *testing.T) {
+	tests := []string{
+		"DTSTART:20260101T090000Z\nRRULE:FREQ=DAILY;COUNT=3",
+		"DTSTART;TZID=America/New_York:20260105T090000\nRRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=2TU,-1FR;BYSETPOS=1,-1;WKST=SU\nEXDATE;TZID=America/New_York:20260310T090000",
+		"DTSTART:20260101T000000Z\nRRULE:FREQ=YEARLY;UNTIL=20301231T000000Z;BYSECOND=0;BYMINUTE=0,30;BYHOUR=9;BYMONTHDAY=-1;BYMONTH=6,12",
+	}
+	for _, text := range tests {
+		s, err := Parse(text)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", text, err)
+			continue
+		}
+		if result := s.Expression(); result != text {
+			t.Errorf("Parse(%q).Expression() = %q WANT %q", text, result, text)
+		}
+	}
+}
+
+func TestSchedule_Expression_sched(t *testing.T) {
+	text := "DTSTART:20260101T090000Z\nRRULE:FREQ=DAILY;COUNT=3"
+	s, err := Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the multiple lines of an Expression are not a sched expression.
+	if _, err := sched.Parse(s.Expression()); err == nil {
+		t.Errorf("sched.Parse(%q) error = <nil> WANT an error", s.Expression())
+	}
+	value, _ := (sched.Expr{Schedule: s}).Value()
+	if err := (&sched.Expr{}).Scan(value); err == nil {
+		t.Errorf("Expr.Scan(%q) error = <nil> WANT an error", value)
+	}
+}
+
+func TestRule_String_frequency(t *testing.T) {
+	for f := Secondly; f <= Yearly; f++ {
+		result := Rule{Freq: f, WeekStart: time.Monday}.String()
+		if !strings.HasPrefix(result, "FREQ="+f.String()) {
+			t.Errorf("Rule{Freq: %v}.String() = %v", f, result)
+		}
+	}
+}
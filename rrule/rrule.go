@@ -0,0 +1,416 @@
+//Package rrule implements iCalendar (RFC 5545) recurrence rules as sched.Schedules.
+package rrule
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type Frequency int
+
+const (
+	Secondly Frequency = iota
+	Minutely
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+//maxYearsSearched bounds how far past a time NextTime looks for an occurrence.
+//The Gregorian calendar repeats every 400 years, so searching any more would not find one.
+const maxYearsSearched = 400
+
+//WeekdayNum is a BYDAY value, such as "MO", "2TU", or "-1FR".
+//N of 0 means every Weekday in the period.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+//Rule is an RRULE.
+//An Interval of zero is the same as 1, and a Count of zero and zero Until are unbounded.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	BySecond   []int
+	ByMinute   []int
+	ByHour     []int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+//Schedule is the recurrence set of a Rule starting at DTStart, without ExDates.
+//Occurrences are computed in the location of DTStart.
+//The Rule and DTStart must not be changed after NextTime is called.
+type Schedule struct {
+	Rule
+	DTStart time.Time
+	ExDates []time.Time
+
+	//lock guards counted.
+	lock sync.Mutex
+
+	//counted is where NextTime last stopped counting occurrences for a Count, so that
+	//later times do not count from DTStart again. It is nil if there is no Count.
+	counted *checkpoint
+}
+
+//checkpoint is the number of occurrences before period k, which all come before time.
+type checkpoint struct {
+	k     int
+	count int
+	time  time.Time
+}
+
+func New(rule Rule, dtstart time.Time, exdates ...time.Time) *Schedule {
+	return &Schedule{
+		Rule:    rule,
+		DTStart: dtstart,
+		ExDates: exdates,
+	}
+}
+
+//NextTime returns the first occurrence of s after from.
+func (s *Schedule) NextTime(from time.Time) (time.Time, bool) {
+	it := newIterator(s)
+	k, count := 0, 0
+	if s.Count == 0 {
+		//occurrences are only counted from the start if there is a Count.
+		k = it.periodOf(wallClock(from.In(s.DTStart.Location()))) - 1
+		if k < 0 {
+			k = 0
+		}
+	} else if c := s.checkpoint(); c != nil && !from.Before(c.time) {
+		k, count = c.k, c.count
+	}
+	limit := wallClock(from.In(s.DTStart.Location())).AddDate(maxYearsSearched, 0, 0)
+	for ; !it.periodStart(k).After(limit); k++ {
+		if skip := it.skip(k); skip > k {
+			k = skip - 1
+			continue
+		}
+		before := count
+		for _, t := range it.occurrences(k) {
+			if t.Before(s.DTStart) {
+				continue
+			}
+			count++
+			if (s.Count > 0 && count > s.Count) || (!s.Until.IsZero() && t.After(s.Until)) {
+				return t, false
+			}
+			if t.After(from) && !s.isExDate(t) {
+				if s.Count > 0 {
+					s.setCheckpoint(&checkpoint{k: k, count: before, time: t})
+				}
+				return t, true
+			}
+		}
+	}
+	return from, false
+}
+
+func (s *Schedule) checkpoint() *checkpoint {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.counted
+}
+
+//setCheckpoint sets the checkpoint of s to c if c is later.
+func (s *Schedule) setCheckpoint(c *checkpoint) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.counted == nil || c.k > s.counted.k {
+		s.counted = c
+	}
+}
+
+func (s *Schedule) isExDate(t time.Time) bool {
+	for _, exdate := range s.ExDates {
+		if exdate.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+//iterator generates the occurrences of a Schedule in each period of its Freq.
+//Periods are numbered from the one containing DTStart, and times are wall clock times in UTC.
+type iterator struct {
+	*Schedule
+	rule  Rule
+	start time.Time
+}
+
+func newIterator(s *Schedule) *iterator {
+	return &iterator{
+		Schedule: s,
+		rule:     s.effectiveRule(),
+		start:    wallClock(s.DTStart),
+	}
+}
+
+//effectiveRule returns the Rule of s with the values that are implied by DTStart filled in.
+func (s *Schedule) effectiveRule() Rule {
+	r := s.Rule
+	if r.Interval <= 0 {
+		r.Interval = 1
+	}
+	hasDays := len(r.ByMonthDay) > 0 || len(r.ByDay) > 0
+	switch r.Freq {
+	case Yearly:
+		if len(r.ByMonth) == 0 && !hasDays {
+			r.ByMonth = []int{int(s.DTStart.Month())}
+		}
+		if !hasDays {
+			r.ByMonthDay = []int{s.DTStart.Day()}
+		}
+	case Monthly:
+		if !hasDays {
+			r.ByMonthDay = []int{s.DTStart.Day()}
+		}
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			r.ByDay = []WeekdayNum{{s.DTStart.Weekday(), 0}}
+		}
+	}
+	return r
+}
+
+//periodStart returns the start of period k.
+func (it *iterator) periodStart(k int) time.Time {
+	n := k * it.rule.Interval
+	y, m, d := it.start.Date()
+	switch it.rule.Freq {
+	case Yearly:
+		return time.Date(y+n, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case Monthly:
+		return time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	case Weekly:
+		offset := (int(it.start.Weekday()) - int(it.rule.WeekStart) + 7) % 7
+		return time.Date(y, m, d-offset+7*n, 0, 0, 0, 0, time.UTC)
+	case Daily:
+		return time.Date(y, m, d+n, 0, 0, 0, 0, time.UTC)
+	}
+	return it.start.Truncate(it.unit()).Add(time.Duration(n) * it.unit())
+}
+
+//unit returns the length of periods shorter than a day.
+func (it *iterator) unit() time.Duration {
+	switch it.rule.Freq {
+	case Hourly:
+		return time.Hour
+	case Minutely:
+		return time.Minute
+	}
+	return time.Second
+}
+
+//periodOf returns the period that contains wall, which may be negative.
+func (it *iterator) periodOf(wall time.Time) int {
+	first := it.periodStart(0)
+	var n int
+	switch it.rule.Freq {
+	case Yearly:
+		n = wall.Year() - first.Year()
+	case Monthly:
+		n = (wall.Year()-first.Year())*12 + int(wall.Month()) - int(first.Month())
+	case Weekly:
+		n = floorDiv(daysBetween(first, wall), 7)
+	case Daily:
+		n = daysBetween(first, wall)
+	default:
+		n = int(floorDiv64(int64(wall.Sub(first)), int64(it.unit())))
+	}
+	return floorDiv(n, it.rule.Interval)
+}
+
+//skip returns the first period after k that could have occurrences if period k, which is
+//shorter than a day, cannot, or k otherwise.
+func (it *iterator) skip(k int) int {
+	if it.rule.Freq >= Daily {
+		return k
+	}
+	start := it.periodStart(k)
+	var next time.Time
+	if !it.dayMatches(start) {
+		next = time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, time.UTC)
+	} else if it.rule.Freq < Hourly && len(it.rule.ByHour) > 0 && !containsInt(it.rule.ByHour, start.Hour()) {
+		next = start.Truncate(time.Hour).Add(time.Hour)
+	} else {
+		return k
+	}
+	step := int64(it.unit()) * int64(it.rule.Interval)
+	return k + int((int64(next.Sub(start))+step-1)/step)
+}
+
+//occurrences returns the sorted occurrences of period k in the location of DTStart.
+func (it *iterator) occurrences(k int) []time.Time {
+	start := it.periodStart(k)
+	wall := []time.Time{}
+	for _, day := range it.days(start) {
+		if !it.dayMatches(day) {
+			continue
+		}
+		for _, hour := range it.values(it.rule.ByHour, Hourly, start.Hour(), it.start.Hour()) {
+			for _, minute := range it.values(it.rule.ByMinute, Minutely, start.Minute(), it.start.Minute()) {
+				for _, second := range it.values(it.rule.BySecond, Secondly, start.Second(), it.start.Second()) {
+					wall = append(wall, time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, it.start.Nanosecond(), time.UTC))
+				}
+			}
+		}
+	}
+	if len(it.rule.BySetPos) > 0 {
+		wall = setPositions(wall, it.rule.BySetPos)
+	}
+	result := make([]time.Time, 0, len(wall))
+	for _, w := range wall {
+		result = append(result, time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), it.DTStart.Location()))
+	}
+	return result
+}
+
+//days returns the days in the period that starts at start.
+func (it *iterator) days(start time.Time) []time.Time {
+	count := 1
+	switch it.rule.Freq {
+	case Yearly:
+		count = daysBetween(start, start.AddDate(1, 0, 0))
+	case Monthly:
+		count = daysBetween(start, start.AddDate(0, 1, 0))
+	case Weekly:
+		count = 7
+	}
+	result := make([]time.Time, 0, count)
+	for i := 0; i < count; i++ {
+		result = append(result, time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, time.UTC))
+	}
+	return result
+}
+
+//values returns the sorted values of a time of day field.
+//If the Freq is at most freq, the field is that of the period, current, limited by by.
+//Otherwise the values are by, or the field of DTStart, initial, if by is empty.
+func (it *iterator) values(by []int, freq Frequency, current, initial int) []int {
+	if it.rule.Freq <= freq {
+		if len(by) > 0 && !containsInt(by, current) {
+			return nil
+		}
+		return []int{current}
+	}
+	if len(by) == 0 {
+		return []int{initial}
+	}
+	result := append([]int{}, by...)
+	sort.Ints(result)
+	return result
+}
+
+//dayMatches returns whether day is allowed by the ByMonth, ByMonthDay, and ByDay values.
+func (it *iterator) dayMatches(day time.Time) bool {
+	r := it.rule
+	if len(r.ByMonth) > 0 && !containsInt(r.ByMonth, int(day.Month())) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := daysInMonth(day)
+		matches := false
+		for _, md := range r.ByMonthDay {
+			matches = matches || md == day.Day() || (md < 0 && days+md+1 == day.Day())
+		}
+		if !matches {
+			return false
+		}
+	}
+	if len(r.ByDay) > 0 {
+		matches := false
+		for _, wn := range r.ByDay {
+			matches = matches || (wn.Weekday == day.Weekday() && (wn.N == 0 || it.isOccurrence(day, wn.N)))
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
+//isOccurrence returns whether day is occurrence n of its weekday in its month, or in its
+//year for yearly rules without ByMonth. Negative n count from the end.
+func (it *iterator) isOccurrence(day time.Time, n int) bool {
+	index, count := day.Day(), daysInMonth(day)
+	if it.rule.Freq == Yearly && len(it.rule.ByMonth) == 0 {
+		index, count = day.YearDay(), daysBetween(time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(day.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+	}
+	if n > 0 {
+		return (index-1)/7+1 == n
+	}
+	return (count-index)/7+1 == -n
+}
+
+//setPositions returns the values of sorted at the 1-based positions, where negative
+//positions count from the end.
+func setPositions(sorted []time.Time, positions []int) []time.Time {
+	result := []time.Time{}
+	for _, pos := range positions {
+		i := pos - 1
+		if pos < 0 {
+			i = len(sorted) + pos
+		}
+		if i >= 0 && i < len(sorted) {
+			result = append(result, sorted[i])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Before(result[j])
+	})
+	deduped := result[:0]
+	for i, t := range result {
+		if i == 0 || !t.Equal(result[i-1]) {
+			deduped = append(deduped, t)
+		}
+	}
+	return deduped
+}
+
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+//daysBetween returns the number of days from the day of a to the day of b.
+func daysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int((b.Unix() - a.Unix()) / (24 * 60 * 60))
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func floorDiv(a, b int) int {
+	return int(floorDiv64(int64(a), int64(b)))
+}
+
+func floorDiv64(a, b int64) int64 {
+	result := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		result--
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogolfing/cron/sched"
+)
+
+var _ sched.Schedule = (*Schedule)(nil)
+
+func TestSchedule_NextTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		text string
+		from time.Time
+		want []time.Time
+	}{
+		{
+			"DTSTART:20260105T090000Z\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE",
+			utc(2026, time.January, 1, 0, 0, 0),
+			[]time.Time{
+				utc(2026, time.January, 5, 9, 0, 0), utc(2026, time.January, 7, 9, 0, 0),
+				utc(2026, time.January, 12, 9, 0, 0), utc(2026, time.January, 14, 9, 0, 0),
+			},
+		},
+		{
+			"DTSTART:20260105T090000Z\nRRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO",
+			utc(2026, time.January, 6, 0, 0, 0),
+			[]time.Time{utc(2026, time.January, 19, 9, 0, 0), utc(2026, time.February, 2, 9, 0, 0)},
+		},
+		{
+			"DTSTART:20260101T100000Z\nRRULE:FREQ=MONTHLY;BYDAY=-1FR",
+			utc(2026, time.January, 1, 0, 0, 0),
+			[]time.Time{
+				utc(2026, time.January, 30, 10, 0, 0), utc(2026, time.February, 27, 10, 0, 0),
+				utc(2026, time.March, 27, 10, 0, 0),
+			},
+		},
+		{
+			"DTSTART:20260131T000000Z\nRRULE:FREQ=MONTHLY;BYMONTHDAY=-1",
+			utc(2026, time.January, 1, 0, 0, 0),
+			[]time.Time{
+				utc(2026, time.January, 31, 0, 0, 0), utc(2026, time.February, 28, 0, 0, 0),
+				utc(2026, time.March, 31, 0, 0, 0), utc(2026, time.April, 30, 0, 0, 0),
+			},
+		},
+		{
+			"DTSTART:20260131T000000Z\nRRULE:FREQ=MONTHLY",
+			utc(2026, time.January, 31, 0, 0, 0),
+			[]time.Time{utc(2026, time.March, 31, 0, 0, 0), utc(2026, time.May, 31, 0, 0, 0)},
+		},
+		{
+			"DTSTART:20260101T170000Z\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			utc(2026, time.January, 1, 0, 0, 0),
+			[]time.Time{
+				utc(2026, time.January, 30, 17, 0, 0), utc(2026, time.February, 27, 17, 0, 0),
+				utc(2026, time.March, 31, 17, 0, 0), utc(2026, time.April, 30, 17, 0, 0),
+				utc(2026, time.May, 29, 17, 0, 0),
+			},
+		},
+		{
+			"DTSTART:20261103T000000Z\nRRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1TU",
+			utc(2026, time.January, 1, 0, 0, 0),
+			[]time.Time{
+				utc(2026, time.November, 3, 0, 0, 0), utc(2027, time.November, 2, 0, 0, 0),
+				utc(2028, time.November, 7, 0, 0, 0),
+			},
+		},
+		{
+			"DTSTART:20240229T000000Z\nRRULE:FREQ=YEARLY",
+			utc(2024, time.March, 1, 0, 0, 0),
+			[]time.Time{utc(2028, time.February, 29, 0, 0, 0), utc(2032, time.February, 29, 0, 0, 0)},
+		},
+		{
+			"DTSTART:20260101T090000Z\nRRULE:FREQ=DAILY;COUNT=3",
+			utc(2025, time.January, 1, 0, 0, 0),
+			[]time.Time{
+				utc(2026, time.January, 1, 9, 0, 0), utc(2026, time.January, 2, 9, 0, 0),
+				utc(2026, time.January, 3, 9, 0, 0), {},
+			},
+		},
+		{
+			"DTSTART:20260101T090000Z\nRRULE:FREQ=DAILY;UNTIL=20260103T090000Z",
+			utc(2026, time.January, 1, 12, 0, 0),
+			[]time.Time{utc(2026, time.January, 2, 9, 0, 0), utc(2026, time.January, 3, 9, 0, 0), {}},
+		},
+		{
+			"DTSTART:20260101T090000Z\nRRULE:FREQ=DAILY;UNTIL=20260102\nEXDATE:20260101T090000Z",
+			utc(2025, time.January, 1, 0, 0, 0),
+			[]time.Time{utc(2026, time.January, 2, 9, 0, 0), {}},
+		},
+		{
+			"DTSTART:20260101T000000Z\nRRULE:FREQ=HOURLY;INTERVAL=6;BYDAY=SA",
+			utc(2026, time.January, 1, 0, 0, 0),
+			[]time.Time{
+				utc(2026, time.January, 3, 0, 0, 0), utc(2026, time.January, 3, 6, 0, 0),
+				utc(2026, time.January, 3, 12, 0, 0), utc(2026, time.January, 3, 18, 0, 0),
+				utc(2026, time.January, 10, 0, 0, 0),
+			},
+		},
+		{
+			"DTSTART:20260101T000000Z\nRRULE:FREQ=MINUTELY;INTERVAL=15;BYHOUR=9",
+			utc(2026, time.January, 1, 9, 50, 0),
+			[]time.Time{utc(2026, time.January, 2, 9, 0, 0), utc(2026, time.January, 2, 9, 15, 0)},
+		},
+		{
+			"DTSTART;TZID=America/New_York:20260301T020000\nRRULE:FREQ=DAILY;BYHOUR=9",
+			utc(2026, time.March, 7, 0, 0, 0),
+			[]time.Time{
+				time.Date(2026, time.March, 7, 9, 0, 0, 0, newYork), time.Date(2026, time.March, 8, 9, 0, 0, 0, newYork),
+				time.Date(2026, time.March, 9, 9, 0, 0, 0, newYork),
+			},
+		},
+	}
+	for _, test := range tests {
+		s, err := Parse(test.text)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", test.text, err)
+			continue
+		}
+		testNextTimes(t, s, test.from, test.want...)
+	}
+}
+
+func TestSchedule_NextTime_beforeDTStart(t *testing.T) {
+	s := New(Rule{Freq: Daily, WeekStart: time.Monday}, utc(2026, time.January, 1, 12, 0, 0))
+	testNextTimes(t, s, utc(2025, time.June, 1, 0, 0, 0), utc(2026, time.January, 1, 12, 0, 0), utc(2026, time.January, 2, 12, 0, 0))
+}
+
+func TestSchedule_NextTime_none(t *testing.T) {
+	s := New(Rule{Freq: Yearly, ByMonth: []int{2}, ByMonthDay: []int{30}}, utc(2026, time.January, 1, 0, 0, 0))
+	testNextTimes(t, s, utc(2026, time.January, 1, 0, 0, 0), time.Time{})
+}
+
+func TestSchedule_NextTime_count(t *testing.T) {
+	start := utc(2026, time.January, 1, 0, 0, 0)
+	s := New(Rule{Freq: Minutely, Count: 100000, WeekStart: time.Monday}, start)
+	from := start.Add(-time.Second)
+	for i := 0; i < 100000; i++ {
+		next, ok := s.NextTime(from)
+		if want := start.Add(time.Duration(i) * time.Minute); !ok || !next.Equal(want) {
+			t.Fatalf("NextTime(%v) = %v, %v WANT %v, true", from, next, ok, want)
+		}
+		from = next
+	}
+	if next, ok := s.NextTime(from); ok {
+		t.Errorf("NextTime(%v) = %v, %v WANT false after the Count", from, next, ok)
+	}
+	//an earlier time than the checkpoint counts from DTStart again.
+	testNextTimes(t, s, start.Add(time.Hour), start.Add(time.Hour+time.Minute))
+}
+
+func TestSetPositions(t *testing.T) {
+	sorted := []time.Time{utc(2026, time.January, 1, 0, 0, 0), utc(2026, time.January, 2, 0, 0, 0), utc(2026, time.January, 3, 0, 0, 0)}
+	tests := []struct {
+		positions []int
+		result    []time.Time
+	}{
+		{[]int{1}, sorted[:1]},
+		{[]int{-1}, sorted[2:]},
+		{[]int{-1, 1}, []time.Time{sorted[0], sorted[2]}},
+		{[]int{2, -2}, sorted[1:2]},
+		{[]int{4, -4}, []time.Time{}},
+	}
+	for _, test := range tests {
+		result := setPositions(sorted, test.positions)
+		if len(result) != len(test.result) {
+			t.Errorf("setPositions(%v) = %v WANT %v", test.positions, result, test.result)
+			continue
+		}
+		for i := range result {
+			if !result[i].Equal(test.result[i]) {
+				t.Errorf("setPositions(%v) = %v WANT %v", test.positions, result, test.result)
+				break
+			}
+		}
+	}
+}
+
+func utc(year int, month time.Month, day, hour, minute, second int) time.Time {
+	return time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+}
+
+func testNextTimes(t *testing.T, s *Schedule, from time.Time, want ...time.Time) {
+	for _, w := range want {
+		next, ok := s.NextTime(from)
+		if w.IsZero() {
+			if ok {
+				t.Errorf("%q.NextTime(%v) = %v, %v WANT false", s.Expression(), from, next, ok)
+			}
+			return
+		}
+		if !ok || !next.Equal(w) {
+			t.Errorf("%q.NextTime(%v) = %v, %v WANT %v, true", s.Expression(), from, next, ok, w)
+			return
+		}
+		from = next
+	}
+}
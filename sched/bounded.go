@@ -0,0 +1,161 @@
+package sched
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//AtSchedule fires once at its time.
+type AtSchedule time.Time
+
+func NewAtSchedule(t time.Time) Schedule {
+	return AtSchedule(t)
+}
+
+func (s AtSchedule) NextTime(from time.Time) (time.Time, bool) {
+	result := time.Time(s)
+	return result, result.After(from)
+}
+
+func (s AtSchedule) String() string {
+	return fmt.Sprintf("sched.AtSchedule(%v)", time.Time(s))
+}
+
+func (s AtSchedule) Expression() string {
+	return fmt.Sprintf("%v %v", At, formatBoundTime(time.Time(s)))
+}
+
+//BoundedSchedule fires at the times of its Schedule that are in [Start, End), and at
+//most MaxRuns times counting from Start.
+//A zero Start or End leaves that side unbounded, and a MaxRuns of zero is unlimited.
+//Start must not be zero if MaxRuns is positive.
+//The Schedule, Start, and MaxRuns must not be changed after NextTime is called.
+type BoundedSchedule struct {
+	Schedule
+	Start   time.Time
+	End     time.Time
+	MaxRuns int
+
+	//lock guards counted.
+	lock sync.Mutex
+
+	//counted is the latest run that NextTime returned, so that later times do not count
+	//runs from Start again. It is nil until NextTime returns a run.
+	counted *runCheckpoint
+}
+
+//runCheckpoint is the run of a BoundedSchedule at time, which is its index'th run.
+type runCheckpoint struct {
+	index int
+	time  time.Time
+}
+
+func NewWindowSchedule(s Schedule, start, end time.Time) *BoundedSchedule {
+	return &BoundedSchedule{
+		Schedule: s,
+		Start:    start,
+		End:      end,
+	}
+}
+
+func NewMaxRunsSchedule(s Schedule, start time.Time, maxRuns int) *BoundedSchedule {
+	return &BoundedSchedule{
+		Schedule: s,
+		Start:    start,
+		MaxRuns:  maxRuns,
+	}
+}
+
+func (s *BoundedSchedule) NextTime(from time.Time) (time.Time, bool) {
+	if s.MaxRuns > 0 {
+		return s.nextRun(from)
+	}
+	var next time.Time
+	var ok bool
+	if !s.Start.IsZero() && from.Before(s.Start) {
+		next, ok = s.first()
+	} else {
+		next, ok = s.Schedule.NextTime(from)
+	}
+	if !ok || !s.isBeforeEnd(next) {
+		return next, false
+	}
+	return next, true
+}
+
+//nextRun returns the first of the MaxRuns times after Start that is after from.
+func (s *BoundedSchedule) nextRun(from time.Time) (time.Time, bool) {
+	if s.Start.IsZero() {
+		return from, false
+	}
+	i := 0
+	next, ok := s.first()
+	if c := s.checkpoint(); c != nil && !from.Before(c.time) {
+		i, next, ok = c.index, c.time, true
+	}
+	for ; i < s.MaxRuns && ok && s.isBeforeEnd(next); i++ {
+		if next.After(from) {
+			s.setCheckpoint(&runCheckpoint{index: i, time: next})
+			return next, true
+		}
+		next, ok = s.Schedule.NextTime(next)
+	}
+	return next, false
+}
+
+func (s *BoundedSchedule) checkpoint() *runCheckpoint {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.counted
+}
+
+//setCheckpoint sets the checkpoint of s to c if c is later.
+func (s *BoundedSchedule) setCheckpoint(c *runCheckpoint) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.counted == nil || c.index > s.counted.index {
+		s.counted = c
+	}
+}
+
+//first returns Start if the Schedule fires at it, or the next time of the Schedule after it.
+func (s *BoundedSchedule) first() (time.Time, bool) {
+	if next, ok := s.Schedule.NextTime(s.Start.Add(-time.Nanosecond)); ok && next.Equal(s.Start) {
+		return next, true
+	}
+	return s.Schedule.NextTime(s.Start)
+}
+
+func (s *BoundedSchedule) isBeforeEnd(t time.Time) bool {
+	return s.End.IsZero() || t.Before(s.End)
+}
+
+func (s *BoundedSchedule) String() string {
+	return fmt.Sprintf("sched.BoundedSchedule(%v, %v, %v, %v)", s.Schedule, s.Start, s.End, s.MaxRuns)
+}
+
+//Expression returns the expression of s. The expression of the Schedule is in braces if the
+//bounds cannot follow it, as they cannot follow a splay or time zone.
+func (s *BoundedSchedule) Expression() string {
+	inner := s.Schedule.Expression()
+	switch s.Schedule.(type) {
+	case *SplaySchedule, *LocationSchedule, *BoundedSchedule:
+		inner = OpenBrace + inner + CloseBrace
+	}
+	result := fmt.Sprintf("%v %v%v%v%v%v", inner,
+		OpenBracket, formatBoundTime(s.Start), Comma, formatBoundTime(s.End), CloseParen,
+	)
+	if s.MaxRuns > 0 {
+		result += fmt.Sprintf(" %v%v", Runs, s.MaxRuns)
+	}
+	return result
+}
+
+//formatBoundTime returns t in RFC 3339 format, or "" if t is zero.
+func formatBoundTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,111 @@
+package sched
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAtSchedule_NextTime(t *testing.T) {
+	at := utc(2026, time.December, 1, 9, 0, 0)
+	s := NewAtSchedule(at)
+	testNextTimes(t, s, utc(2026, time.November, 1, 0, 0, 0), at, time.Time{})
+	testNextTimes(t, s, at, time.Time{})
+}
+
+func TestAtSchedule_Expression(t *testing.T) {
+	s := NewAtSchedule(utc(2026, time.December, 1, 9, 0, 0))
+	want := At + " 2026-12-01T09:00:00Z"
+	if result := s.Expression(); result != want {
+		t.Errorf("%v.Expression() = %v WANT %v", s, result, want)
+	}
+}
+
+func TestBoundedSchedule_NextTime_window(t *testing.T) {
+	s := NewWindowSchedule(MustParse(Daily), utc(2016, time.March, 2, 0, 0, 0), utc(2016, time.March, 4, 0, 0, 0))
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0),
+		utc(2016, time.March, 2, 0, 0, 0), utc(2016, time.March, 3, 0, 0, 0), time.Time{},
+	)
+
+	s = NewWindowSchedule(MustParse(Daily), time.Time{}, utc(2016, time.March, 2, 0, 0, 0))
+	testNextTimes(t, s, utc(2016, time.February, 28, 12, 0, 0),
+		utc(2016, time.February, 29, 0, 0, 0), utc(2016, time.March, 1, 0, 0, 0), time.Time{},
+	)
+
+	s = NewWindowSchedule(NewIntervalSchedule(time.Hour), utc(2016, time.March, 2, 0, 0, 0), time.Time{})
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0),
+		utc(2016, time.March, 2, 1, 0, 0), utc(2016, time.March, 2, 2, 0, 0),
+	)
+}
+
+func TestBoundedSchedule_NextTime_maxRuns(t *testing.T) {
+	s := NewMaxRunsSchedule(MustParse(Daily), utc(2016, time.March, 2, 0, 0, 0), 3)
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0),
+		utc(2016, time.March, 2, 0, 0, 0), utc(2016, time.March, 3, 0, 0, 0), utc(2016, time.March, 4, 0, 0, 0), time.Time{},
+	)
+	testNextTimes(t, s, utc(2016, time.March, 3, 12, 0, 0), utc(2016, time.March, 4, 0, 0, 0), time.Time{})
+
+	s.End = utc(2016, time.March, 3, 12, 0, 0)
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0),
+		utc(2016, time.March, 2, 0, 0, 0), utc(2016, time.March, 3, 0, 0, 0), time.Time{},
+	)
+
+	s = NewMaxRunsSchedule(MustParse(Daily), time.Time{}, 3)
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0), time.Time{})
+}
+
+//countingSchedule counts the calls to the NextTime of its Schedule.
+type countingSchedule struct {
+	Schedule
+	calls int
+}
+
+func (s *countingSchedule) NextTime(from time.Time) (time.Time, bool) {
+	s.calls++
+	return s.Schedule.NextTime(from)
+}
+
+func TestBoundedSchedule_NextTime_checkpoint(t *testing.T) {
+	start := utc(2016, time.March, 2, 0, 0, 0)
+	inner := &countingSchedule{Schedule: MustParse(Daily)}
+	s := NewMaxRunsSchedule(inner, start, 1000)
+	next, ok := start.Add(-time.Second), true
+	for i := 0; i < 1000; i++ {
+		if next, ok = s.NextTime(next); !ok || !next.Equal(start.AddDate(0, 0, i)) {
+			t.Fatalf("NextTime() run %v = %v, %v WANT %v, true", i, next, ok, start.AddDate(0, 0, i))
+		}
+	}
+	if next, ok = s.NextTime(next); ok {
+		t.Errorf("NextTime() after the last run = %v, %v WANT false", next, ok)
+	}
+	//each run continues counting from the one before it instead of from Start.
+	if inner.calls > 3*1000 {
+		t.Errorf("Schedule.NextTime() calls = %v WANT at most %v", inner.calls, 3*1000)
+	}
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0), start, start.AddDate(0, 0, 1))
+}
+
+func TestBoundedSchedule_Expression(t *testing.T) {
+	start, end := utc(2026, time.January, 1, 0, 0, 0), utc(2027, time.January, 1, 0, 0, 0)
+	tests := []struct {
+		s      *BoundedSchedule
+		result string
+	}{
+		{NewWindowSchedule(MustParse(Daily), start, end), "0 0 0 * * * * [2026-01-01T00:00:00Z,2027-01-01T00:00:00Z)"},
+		{NewWindowSchedule(MustParse(Daily), start, time.Time{}), "0 0 0 * * * * [2026-01-01T00:00:00Z,)"},
+		{NewWindowSchedule(MustParse(Daily), time.Time{}, end), "0 0 0 * * * * [,2027-01-01T00:00:00Z)"},
+		{NewMaxRunsSchedule(MustParse(Daily), start, 3), "0 0 0 * * * * [2026-01-01T00:00:00Z,) x3"},
+		{NewWindowSchedule(MustParse("@daily ~5m"), start, end), "{0 0 0 * * * * ~5m0s} [2026-01-01T00:00:00Z,2027-01-01T00:00:00Z)"},
+		{NewMaxRunsSchedule(MustParse("CRON_TZ=America/New_York @daily"), start, 3), "{CRON_TZ=America/New_York 0 0 0 * * * *} [2026-01-01T00:00:00Z,) x3"},
+		{NewMaxRunsSchedule(NewWindowSchedule(MustParse(Daily), start, end), start, 3), "{0 0 0 * * * * [2026-01-01T00:00:00Z,2027-01-01T00:00:00Z)} [2026-01-01T00:00:00Z,) x3"},
+		{NewWindowSchedule(MustParse("@daily; @hourly"), start, end), "{0 0 0 * * * *; 0 0 * * * * *} [2026-01-01T00:00:00Z,2027-01-01T00:00:00Z)"},
+	}
+	for _, test := range tests {
+		result := test.s.Expression()
+		if result != test.result {
+			t.Errorf("%v.Expression() = %v WANT %v", test.s, result, test.result)
+		}
+		if again := MustParse(result).Expression(); again != result {
+			t.Errorf("MustParse(%q).Expression() = %v WANT %v", result, again, result)
+		}
+	}
+}
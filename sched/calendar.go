@@ -0,0 +1,230 @@
+package sched
+
+import (
+	"fmt"
+	"time"
+)
+
+//Calendar excludes times from schedules, such as holidays or maintenance windows.
+type Calendar interface {
+	//IsExcluded returns whether t is excluded.
+	IsExcluded(t time.Time) bool
+
+	//NextIncluded returns the first time at or after t that is not excluded, and false
+	//if there is none.
+	NextIncluded(t time.Time) (time.Time, bool)
+}
+
+//CalendarSchedule fires at the times of its Schedule that its Calendar does not exclude.
+type CalendarSchedule struct {
+	Schedule
+	Calendar Calendar
+}
+
+func NewCalendarSchedule(s Schedule, c Calendar) *CalendarSchedule {
+	return &CalendarSchedule{
+		Schedule: s,
+		Calendar: c,
+	}
+}
+
+//NextTime returns false if every time of the Schedule within maxYearsSearched years is
+//excluded.
+func (s *CalendarSchedule) NextTime(from time.Time) (time.Time, bool) {
+	limit := from.AddDate(maxYearsSearched, 0, 0)
+	next, ok := s.Schedule.NextTime(from)
+	for ok && s.Calendar.IsExcluded(next) {
+		if !next.Before(limit) {
+			return next, false
+		}
+		var included time.Time
+		included, ok = s.Calendar.NextIncluded(next)
+		if ok {
+			next, ok = ceilTime(s.Schedule, included)
+		}
+	}
+	return next, ok
+}
+
+func (s *CalendarSchedule) String() string {
+	return fmt.Sprintf("sched.CalendarSchedule(%v, %v)", s.Schedule, s.Calendar)
+}
+
+//Expression returns the expression of the Schedule, which does not include the Calendar.
+func (s *CalendarSchedule) Expression() string {
+	return s.Schedule.Expression()
+}
+
+//MultiCalendar excludes the times that any of its calendars exclude.
+type MultiCalendar []Calendar
+
+func (mc MultiCalendar) IsExcluded(t time.Time) bool {
+	for _, c := range mc {
+		if c.IsExcluded(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mc MultiCalendar) NextIncluded(t time.Time) (time.Time, bool) {
+	limit := t.AddDate(maxYearsSearched, 0, 0)
+	for t.Before(limit) {
+		excluded := false
+		for _, c := range mc {
+			if !c.IsExcluded(t) {
+				continue
+			}
+			excluded = true
+			var ok bool
+			if t, ok = c.NextIncluded(t); !ok {
+				return t, false
+			}
+		}
+		if !excluded {
+			return t, true
+		}
+	}
+	return t, false
+}
+
+//dayLayout formats the calendar dates that DateCalendar uses.
+const dayLayout = "2006-01-02"
+
+//DateCalendar excludes whole calendar days, in the location of the times it is given.
+type DateCalendar map[string]bool
+
+//NewDateCalendar returns a DateCalendar that excludes the calendar days of dates, in
+//their own locations.
+func NewDateCalendar(dates ...time.Time) DateCalendar {
+	dc := DateCalendar{}
+	for _, date := range dates {
+		dc.Add(date.Year(), date.Month(), date.Day())
+	}
+	return dc
+}
+
+func (dc DateCalendar) Add(year int, month time.Month, day int) {
+	dc[time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Format(dayLayout)] = true
+}
+
+func (dc DateCalendar) IsExcluded(t time.Time) bool {
+	return dc[t.Format(dayLayout)]
+}
+
+func (dc DateCalendar) NextIncluded(t time.Time) (time.Time, bool) {
+	return nextIncludedDay(dc, t, len(dc)+1)
+}
+
+//WeeklyCalendar excludes days of the week.
+type WeeklyCalendar [7]bool
+
+func NewWeeklyCalendar(days ...time.Weekday) *WeeklyCalendar {
+	wc := &WeeklyCalendar{}
+	for _, day := range days {
+		wc[day] = true
+	}
+	return wc
+}
+
+func (wc *WeeklyCalendar) IsExcluded(t time.Time) bool {
+	return wc[t.Weekday()]
+}
+
+func (wc *WeeklyCalendar) NextIncluded(t time.Time) (time.Time, bool) {
+	return nextIncludedDay(wc, t, len(wc))
+}
+
+//AnnualCalendar excludes the same days of the year every year, such as December 25.
+type AnnualCalendar map[time.Month]map[int]bool
+
+func NewAnnualCalendar() AnnualCalendar {
+	return AnnualCalendar{}
+}
+
+func (ac AnnualCalendar) Add(month time.Month, day int) {
+	if ac[month] == nil {
+		ac[month] = map[int]bool{}
+	}
+	ac[month][day] = true
+}
+
+func (ac AnnualCalendar) IsExcluded(t time.Time) bool {
+	return ac[t.Month()][t.Day()]
+}
+
+func (ac AnnualCalendar) NextIncluded(t time.Time) (time.Time, bool) {
+	//February 29 only comes around every four years.
+	return nextIncludedDay(ac, t, 4*366)
+}
+
+//nextIncludedDay returns the start of the first day at or after t that c does not exclude,
+//or t if it is not excluded, looking through at most maxDays days.
+func nextIncludedDay(c Calendar, t time.Time, maxDays int) (time.Time, bool) {
+	for i := 0; i <= maxDays; i++ {
+		if !c.IsExcluded(t) {
+			return t, true
+		}
+		t = wallDate(t.Year(), t.Month(), t.Day()+1, 0, t.Location())
+	}
+	return t, false
+}
+
+//ScheduleCalendar excludes every second that its Schedule fires at, such as with
+//MustParse("* * 0-7,18-23 * * *") for every second outside of business hours.
+type ScheduleCalendar struct {
+	Schedule
+}
+
+func NewScheduleCalendar(s Schedule) *ScheduleCalendar {
+	return &ScheduleCalendar{
+		Schedule: s,
+	}
+}
+
+func (sc *ScheduleCalendar) IsExcluded(t time.Time) bool {
+	second := t.Truncate(time.Second)
+	next, ok := ceilTime(sc.Schedule, second)
+	return ok && next.Equal(second)
+}
+
+func (sc *ScheduleCalendar) NextIncluded(t time.Time) (time.Time, bool) {
+	if !sc.IsExcluded(t) {
+		return t, true
+	}
+	return nextUnmatched(sc.Schedule, t.Truncate(time.Second))
+}
+
+//IntervalCalendar excludes the times in its intervals.
+type IntervalCalendar []Interval
+
+//Interval is the times in [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (ic IntervalCalendar) IsExcluded(t time.Time) bool {
+	for _, interval := range ic {
+		if !t.Before(interval.Start) && t.Before(interval.End) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ic IntervalCalendar) NextIncluded(t time.Time) (time.Time, bool) {
+	//intervals may overlap, so each one may move t into another.
+	for i := 0; i <= len(ic); i++ {
+		moved := false
+		for _, interval := range ic {
+			if !t.Before(interval.Start) && t.Before(interval.End) {
+				t, moved = interval.End, true
+			}
+		}
+		if !moved {
+			return t, true
+		}
+	}
+	return t, false
+}
@@ -0,0 +1,112 @@
+package sched
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarSchedule_NextTime(t *testing.T) {
+	holidays := NewAnnualCalendar()
+	holidays.Add(time.December, 25)
+	holidays.Add(time.January, 1)
+	s := NewCalendarSchedule(MustParse("0 9 * * *"), MultiCalendar{holidays, NewWeeklyCalendar(time.Saturday, time.Sunday)})
+	testNextTimes(t, s, utc(2017, time.December, 22, 12, 0, 0),
+		utc(2017, time.December, 26, 9, 0, 0), utc(2017, time.December, 27, 9, 0, 0), utc(2017, time.December, 28, 9, 0, 0),
+		utc(2017, time.December, 29, 9, 0, 0), utc(2018, time.January, 2, 9, 0, 0),
+	)
+
+	s = NewCalendarSchedule(MustParse(Secondly), NewScheduleCalendar(MustParse("* * 0-7,18-23 * * *")))
+	testNextTimes(t, s, utc(2016, time.March, 1, 17, 59, 58),
+		utc(2016, time.March, 1, 17, 59, 59), utc(2016, time.March, 2, 8, 0, 0), utc(2016, time.March, 2, 8, 0, 1),
+	)
+
+	s = NewCalendarSchedule(MustParse(Daily), NewWeeklyCalendar(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday))
+	testNextTimes(t, s, utc(2016, time.March, 1, 0, 0, 0), time.Time{})
+
+	s = NewCalendarSchedule(NewAtSchedule(utc(2016, time.March, 1, 0, 0, 0)), NewDateCalendar(utc(2016, time.March, 1, 0, 0, 0)))
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0), time.Time{})
+}
+
+func TestCalendarSchedule_Expression(t *testing.T) {
+	s := NewCalendarSchedule(MustParse(Daily), NewWeeklyCalendar(time.Sunday))
+	if result := s.Expression(); result != MustParse(Daily).Expression() {
+		t.Errorf("%v.Expression() = %v WANT %v", s, result, MustParse(Daily).Expression())
+	}
+}
+
+func TestDateCalendar(t *testing.T) {
+	dc := NewDateCalendar(utc(2016, time.March, 1, 12, 0, 0))
+	dc.Add(2016, time.March, 2)
+	testCalendar(t, dc, []calendarTest{
+		{utc(2016, time.February, 29, 23, 59, 59), false, utc(2016, time.February, 29, 23, 59, 59)},
+		{utc(2016, time.March, 1, 0, 0, 0), true, utc(2016, time.March, 3, 0, 0, 0)},
+		{utc(2016, time.March, 2, 18, 0, 0), true, utc(2016, time.March, 3, 0, 0, 0)},
+		{utc(2017, time.March, 1, 0, 0, 0), false, utc(2017, time.March, 1, 0, 0, 0)},
+	})
+}
+
+func TestWeeklyCalendar(t *testing.T) {
+	wc := NewWeeklyCalendar(time.Saturday, time.Sunday)
+	testCalendar(t, wc, []calendarTest{
+		{utc(2016, time.March, 4, 23, 0, 0), false, utc(2016, time.March, 4, 23, 0, 0)},
+		{utc(2016, time.March, 5, 10, 0, 0), true, utc(2016, time.March, 7, 0, 0, 0)},
+	})
+}
+
+func TestAnnualCalendar(t *testing.T) {
+	ac := NewAnnualCalendar()
+	ac.Add(time.February, 29)
+	ac.Add(time.March, 1)
+	testCalendar(t, ac, []calendarTest{
+		{utc(2016, time.February, 28, 0, 0, 0), false, utc(2016, time.February, 28, 0, 0, 0)},
+		{utc(2016, time.February, 29, 6, 0, 0), true, utc(2016, time.March, 2, 0, 0, 0)},
+		{utc(2017, time.March, 1, 6, 0, 0), true, utc(2017, time.March, 2, 0, 0, 0)},
+	})
+}
+
+func TestScheduleCalendar(t *testing.T) {
+	sc := NewScheduleCalendar(MustParse("* 0-29 * * * *"))
+	testCalendar(t, sc, []calendarTest{
+		{utc(2016, time.March, 1, 0, 30, 0), false, utc(2016, time.March, 1, 0, 30, 0)},
+		{utc(2016, time.March, 1, 0, 29, 59).Add(time.Millisecond), true, utc(2016, time.March, 1, 0, 30, 0)},
+		{utc(2016, time.March, 1, 1, 0, 0), true, utc(2016, time.March, 1, 1, 30, 0)},
+	})
+}
+
+func TestScheduleCalendar_weekend(t *testing.T) {
+	sc := NewScheduleCalendar(MustParse("* * * * * 0,6"))
+	monday := utc(2016, time.March, 7, 0, 0, 0)
+	testCalendar(t, sc, []calendarTest{
+		{utc(2016, time.March, 5, 12, 0, 0), true, monday},
+	})
+	testNextTimes(t, NewCalendarSchedule(MustParse(Secondly), sc), utc(2016, time.March, 5, 12, 0, 0), monday, monday.Add(time.Second))
+}
+
+func TestIntervalCalendar(t *testing.T) {
+	ic := IntervalCalendar{
+		{utc(2016, time.March, 1, 10, 0, 0), utc(2016, time.March, 1, 11, 0, 0)},
+		{utc(2016, time.March, 1, 10, 30, 0), utc(2016, time.March, 1, 12, 0, 0)},
+	}
+	testCalendar(t, ic, []calendarTest{
+		{utc(2016, time.March, 1, 9, 0, 0), false, utc(2016, time.March, 1, 9, 0, 0)},
+		{utc(2016, time.March, 1, 10, 0, 0), true, utc(2016, time.March, 1, 12, 0, 0)},
+		{utc(2016, time.March, 1, 12, 0, 0), false, utc(2016, time.March, 1, 12, 0, 0)},
+	})
+}
+
+type calendarTest struct {
+	t        time.Time
+	excluded bool
+	included time.Time
+}
+
+func testCalendar(t *testing.T, c Calendar, tests []calendarTest) {
+	for _, test := range tests {
+		if excluded := c.IsExcluded(test.t); excluded != test.excluded {
+			t.Errorf("%v.IsExcluded(%v) = %v WANT %v", c, test.t, excluded, test.excluded)
+		}
+		if included, ok := c.NextIncluded(test.t); !ok || !included.Equal(test.included) {
+			t.Errorf("%v.NextIncluded(%v) = %v, %v WANT %v, true", c, test.t, included, ok, test.included)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package sched
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//maxCompositeSteps bounds the number of times Intersect schedules ask their schedules for
+//next times, and the number of seconds probed for the end of the times of a Schedule that
+//nextUnmatched does not know, before deciding there are none.
+const maxCompositeSteps = 100000
+
+//UnionSchedule fires whenever any of its schedules fire.
+type UnionSchedule []Schedule
+
+func Union(schedules ...Schedule) Schedule {
+	return UnionSchedule(schedules)
+}
+
+func (s UnionSchedule) NextTime(from time.Time) (time.Time, bool) {
+	result, found := from, false
+	for _, schedule := range s {
+		next, ok := schedule.NextTime(from)
+		if ok && (!found || next.Before(result)) {
+			result, found = next, true
+		}
+	}
+	return result, found
+}
+
+func (s UnionSchedule) String() string {
+	return fmt.Sprintf("sched.UnionSchedule(%v)", []Schedule(s))
+}
+
+func (s UnionSchedule) Expression() string {
+	return compositeExpression(Semicolon+" ", s...)
+}
+
+//IntersectSchedule fires whenever all of its schedules fire at the same time.
+type IntersectSchedule []Schedule
+
+func Intersect(schedules ...Schedule) Schedule {
+	return IntersectSchedule(schedules)
+}
+
+func (s IntersectSchedule) NextTime(from time.Time) (time.Time, bool) {
+	if len(s) == 0 {
+		return from, false
+	}
+	result, ok := s[0].NextTime(from)
+	//agreed is the number of schedules in a row that fire at result.
+	for agreed, step := 0, 0; ok && agreed < len(s); step++ {
+		if step >= maxCompositeSteps {
+			return result, false
+		}
+		var next time.Time
+		next, ok = ceilTime(s[step%len(s)], result)
+		if ok && next.Equal(result) {
+			agreed++
+		} else {
+			result, agreed = next, 1
+		}
+	}
+	return result, ok
+}
+
+func (s IntersectSchedule) String() string {
+	return fmt.Sprintf("sched.IntersectSchedule(%v)", []Schedule(s))
+}
+
+func (s IntersectSchedule) Expression() string {
+	return compositeExpression(" "+KeywordAnd+" ", s...)
+}
+
+//ExceptSchedule fires whenever Base fires and Exclude does not.
+type ExceptSchedule struct {
+	Base    Schedule
+	Exclude Schedule
+}
+
+func Except(base, exclude Schedule) Schedule {
+	return &ExceptSchedule{
+		Base:    base,
+		Exclude: exclude,
+	}
+}
+
+//NextTime skips every time of Base while Exclude keeps firing at once, so that a long
+//exclusion, such as a whole weekend of seconds, takes few steps.
+//It returns false if Base has no time that is not excluded within maxYearsSearched years.
+func (s *ExceptSchedule) NextTime(from time.Time) (time.Time, bool) {
+	limit := from.AddDate(maxYearsSearched, 0, 0)
+	result, ok := s.Base.NextTime(from)
+	for ok && result.Before(limit) {
+		if !firesAt(s.Exclude, result) {
+			return result, true
+		}
+		var end time.Time
+		if end, ok = nextUnmatched(s.Exclude, result); ok {
+			result, ok = ceilTime(s.Base, end)
+		}
+	}
+	return result, false
+}
+
+func (s *ExceptSchedule) String() string {
+	return fmt.Sprintf("sched.ExceptSchedule(%v, %v)", s.Base, s.Exclude)
+}
+
+func (s *ExceptSchedule) Expression() string {
+	return compositeExpression(" "+KeywordExcept+" ", s.Base, s.Exclude)
+}
+
+//ceilTime returns the first time of s that is at or after t.
+func ceilTime(s Schedule, t time.Time) (time.Time, bool) {
+	return s.NextTime(t.Add(-time.Nanosecond))
+}
+
+//firesAt returns whether s fires at t.
+func firesAt(s Schedule, t time.Time) bool {
+	next, ok := ceilTime(s, t)
+	return ok && next.Equal(t)
+}
+
+//nextUnmatched returns the first whole second at or after t that s does not fire at, and
+//false if there is none within maxYearsSearched years.
+func nextUnmatched(s Schedule, t time.Time) (time.Time, bool) {
+	t = ceilSecond(t)
+	switch s := s.(type) {
+	case *schedule:
+		return s.nextUnmatched(t)
+	case Expr:
+		return nextUnmatched(s.Schedule, t)
+	case *LocationSchedule:
+		return nextUnmatched(s.Schedule, t.In(s.Location))
+	case UnionSchedule:
+		//t must be a time that none of the schedules fire at.
+		limit := t.AddDate(maxYearsSearched, 0, 0)
+		for moved := true; moved; {
+			moved = false
+			for _, schedule := range s {
+				if !firesAt(schedule, t) {
+					continue
+				}
+				var ok bool
+				if t, ok = nextUnmatched(schedule, t); !ok || !t.Before(limit) {
+					return t, false
+				}
+				moved = true
+			}
+		}
+		return t, true
+	case IntersectSchedule:
+		result, found := t, false
+		for _, schedule := range s {
+			next, ok := nextUnmatched(schedule, t)
+			if ok && (!found || next.Before(result)) {
+				result, found = next, true
+			}
+		}
+		return result, found
+	case *ExceptSchedule:
+		result, ok := nextUnmatched(s.Base, t)
+		if excluded, isExcluded := ceilTime(s.Exclude, t); isExcluded && (!ok || excluded.Before(result)) {
+			return excluded, true
+		}
+		return result, ok
+	}
+	for step := 0; step < maxCompositeSteps; step++ {
+		if !firesAt(s, t) {
+			return t, true
+		}
+		t = t.Add(time.Second)
+	}
+	return t, false
+}
+
+//ceilSecond returns the first whole second at or after t.
+func ceilSecond(t time.Time) time.Time {
+	result := t.Truncate(time.Second)
+	if result.Before(t) {
+		result = result.Add(time.Second)
+	}
+	return result
+}
+
+//compositeExpression joins the expressions of schedules with separator in braces so that
+//composite schedules can be nested.
+func compositeExpression(separator string, schedules ...Schedule) string {
+	parts := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		parts = append(parts, schedule.Expression())
+	}
+	return OpenBrace + strings.Join(parts, separator) + CloseBrace
+}
@@ -0,0 +1,70 @@
+package sched
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnionSchedule_NextTime(t *testing.T) {
+	s := Union(MustParse("0 9 * * *"), MustParse("30 17 * * *"), NewAtSchedule(utc(2016, time.March, 1, 12, 0, 0)))
+	testNextTimes(t, s, utc(2016, time.March, 1, 0, 0, 0),
+		utc(2016, time.March, 1, 9, 0, 0), utc(2016, time.March, 1, 12, 0, 0), utc(2016, time.March, 1, 17, 30, 0),
+		utc(2016, time.March, 2, 9, 0, 0),
+	)
+	testNextTimes(t, Union(), utc(2016, time.March, 1, 0, 0, 0), time.Time{})
+}
+
+func TestIntersectSchedule_NextTime(t *testing.T) {
+	s := Intersect(MustParse("0 0 * * FRI"), MustParse("0 0 13 * *"))
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0),
+		utc(2016, time.May, 13, 0, 0, 0), utc(2017, time.January, 13, 0, 0, 0), utc(2017, time.October, 13, 0, 0, 0),
+	)
+	s = Intersect(MustParse("*/15 * * * *"), MustParse("*/20 * * * *"), MustParse("0-30 * * * *"))
+	testNextTimes(t, s, utc(2016, time.January, 1, 0, 0, 0),
+		utc(2016, time.January, 1, 1, 0, 0), utc(2016, time.January, 1, 2, 0, 0),
+	)
+	testNextTimes(t, Intersect(MustParse("0 0 * * MON"), MustParse("0 0 * * TUE")), utc(2016, time.January, 1, 0, 0, 0), time.Time{})
+	testNextTimes(t, Intersect(MustParse(Daily), NewAtSchedule(utc(2016, time.January, 1, 0, 0, 0))), utc(2016, time.January, 1, 0, 0, 0), time.Time{})
+	testNextTimes(t, Intersect(), utc(2016, time.January, 1, 0, 0, 0), time.Time{})
+}
+
+func TestExceptSchedule_NextTime(t *testing.T) {
+	s := Except(MustParse("0 9,17 * * MON-FRI"), MustParse("* * 25 12 *"))
+	testNextTimes(t, s, utc(2017, time.December, 22, 12, 0, 0),
+		utc(2017, time.December, 22, 17, 0, 0), utc(2017, time.December, 26, 9, 0, 0), utc(2017, time.December, 26, 17, 0, 0),
+	)
+	testNextTimes(t, Except(MustParse(Daily), MustParse(Secondly)), utc(2016, time.January, 1, 0, 0, 0), time.Time{})
+	testNextTimes(t, Except(MustParse("0 0 0 * * 0"), MustParse("* * * * * 0")), utc(2016, time.January, 1, 0, 0, 0), time.Time{})
+}
+
+func TestExceptSchedule_NextTime_longExclusions(t *testing.T) {
+	saturday := utc(2016, time.March, 5, 12, 0, 0)
+	monday := utc(2016, time.March, 7, 0, 0, 0)
+	testNextTimes(t, MustParse("* * * * * * EXCEPT * * * * * 0,6"), saturday, monday, monday.Add(time.Second))
+
+	s := Except(MustParse(Secondly), Union(MustParse("* * * * * 0,6"), MustParse("* * 0-8 * * *"), MustParse("* * 12 * * * EXCEPT * 0-29 12 * * *")))
+	testNextTimes(t, s, saturday, monday.Add(9*time.Hour), monday.Add(9*time.Hour+time.Second))
+	testNextTimes(t, s, monday.Add(11*time.Hour+59*time.Minute+59*time.Second), monday.Add(12*time.Hour), monday.Add(12*time.Hour+time.Second))
+	testNextTimes(t, s, monday.Add(12*time.Hour+29*time.Minute+59*time.Second), monday.Add(13*time.Hour))
+}
+
+func TestComposite_Expression(t *testing.T) {
+	tests := []struct {
+		s      Schedule
+		result string
+	}{
+		{Union(MustParse(Daily), MustParse(Hourly)), "{0 0 0 * * * *; 0 0 * * * * *}"},
+		{Intersect(MustParse(Daily), MustParse(Weekdays)), "{0 0 0 * * * * AND 0 0 0 * * 1-5 *}"},
+		{Except(Union(MustParse(Daily), MustParse(Hourly)), MustParse(Weekends)), "{{0 0 0 * * * *; 0 0 * * * * *} EXCEPT 0 0 0 * * 0,6 *}"},
+		{NewSplaySchedule(Union(MustParse(Daily), NewIntervalSchedule(time.Hour)), "", time.Minute), "{0 0 0 * * * *; @every 1h0m0s} ~1m0s"},
+	}
+	for _, test := range tests {
+		result := test.s.Expression()
+		if result != test.result {
+			t.Errorf("%v.Expression() = %v WANT %v", test.s, result, test.result)
+		}
+		if again := MustParse(result).Expression(); again != result {
+			t.Errorf("MustParse(%q).Expression() = %v WANT %v", result, again, result)
+		}
+	}
+}
@@ -0,0 +1,642 @@
+package sched
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+//maxListedTimes is the most times of day that a description lists, as in
+//"At 09:00 and 17:00", before it describes the second, minute, and hour fields separately.
+const maxListedTimes = 4
+
+//Unit is the singular and plural names of a unit of time.
+type Unit struct {
+	One  string
+	Many string
+}
+
+func (u Unit) of(n int) string {
+	if n == 1 {
+		return u.One
+	}
+	return u.Many
+}
+
+//Locale is the table of words and fmt formats that Describe builds descriptions from.
+//The comment on each format gives its arguments in order.
+//Formats may use explicit argument indexes, such as "%[2]v", to reorder or skip them.
+type Locale struct {
+	Second Unit
+	Minute Unit
+	Hour   Unit
+	Day    Unit
+	Week   Unit
+	Month  Unit
+	Year   Unit
+
+	//Months starts with January and Weekdays starts with Sunday.
+	Months   [12]string
+	Weekdays [7]string
+
+	//Ordinal returns the word for the nth of something, such as "third" for 3.
+	Ordinal func(n int) string
+
+	//List joins items, such as "a, b, and c".
+	List func(items []string) string
+
+	TimeLayout string //the layout of times given to Once, Starting, and Until
+
+	Quantity string //count, unit
+	Range    string //first, last
+	AtTimes  string //list of times of day
+	Every    string //unit, or a list of quantities
+	EveryN   string //count, units
+	//EveryNFrom is a step that does not cover the whole field, as in "5-59/15".
+	EveryNFrom string //count, units, first, last
+
+	//the formats of values and ranges of each field.
+	AtSeconds     string //unit, list
+	AtMinutes     string //unit, list
+	DuringHours   string //unit, list
+	OnDaysOfMonth string //unit, list
+	InMonths      string //unit, list
+	OnWeekdays    string //unit, list
+	InYears       string //unit, list
+
+	EveryDay            string
+	EveryWeekday        string
+	EveryWeekendDay     string
+	LastDayOfMonth      string
+	NthToLastDayOfMonth string //ordinal
+	LastWeekdayOfMonth  string
+	NearestWeekday      string //day of month
+	NthWeekday          string //ordinal, list of weekdays
+	LastWeekday         string //list of weekdays
+	NthToLastWeekday    string //ordinal, list of weekdays
+
+	//DaysAnd and DaysOr combine day of month and day of week descriptions, depending on
+	//whether a day must match both or either of them.
+	DaysAnd string //day of month, day of week
+	DaysOr  string //day of month, day of week
+
+	Offset   string //duration
+	Reboot   string
+	Once     string //time
+	Starting string //time
+	Until    string //time
+	MaxRuns  string //count
+	Splay    string //duration
+	Location string //description, time zone
+
+	Union     string //description, description
+	Intersect string //description, description
+	Except    string //description, description
+	Calendar  string //description
+}
+
+//English is the Locale that Describe uses.
+var English = &Locale{
+	Second: Unit{"second", "seconds"},
+	Minute: Unit{"minute", "minutes"},
+	Hour:   Unit{"hour", "hours"},
+	Day:    Unit{"day", "days"},
+	Week:   Unit{"week", "weeks"},
+	Month:  Unit{"month", "months"},
+	Year:   Unit{"year", "years"},
+
+	Months: [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	Weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+
+	Ordinal: englishOrdinal,
+	List:    englishList,
+
+	TimeLayout: "2006-01-02 15:04:05 MST",
+
+	Quantity:   "%v %v",
+	Range:      "%v through %v",
+	AtTimes:    "at %v",
+	Every:      "every %v",
+	EveryN:     "every %v %v",
+	EveryNFrom: "every %v %v from %v through %v",
+
+	AtSeconds:     "at %v %v",
+	AtMinutes:     "at %v %v",
+	DuringHours:   "during %v %v",
+	OnDaysOfMonth: "on %v %v of each month",
+	InMonths:      "in %[2]v",
+	OnWeekdays:    "on %[2]v",
+	InYears:       "in %[2]v",
+
+	EveryDay:            "every day",
+	EveryWeekday:        "on every weekday",
+	EveryWeekendDay:     "on weekends",
+	LastDayOfMonth:      "on the last day of each month",
+	NthToLastDayOfMonth: "on the %v to last day of each month",
+	LastWeekdayOfMonth:  "on the last weekday of each month",
+	NearestWeekday:      "on the weekday nearest day %v of each month",
+	NthWeekday:          "on the %v %v of each month",
+	LastWeekday:         "on the last %v of each month",
+	NthToLastWeekday:    "on the %v to last %v of each month",
+
+	DaysAnd: "%v and %v",
+	DaysOr:  "%v or %v",
+
+	Offset:   "offset by %v",
+	Reboot:   "at startup",
+	Once:     "once at %v",
+	Starting: "starting %v",
+	Until:    "until %v",
+	MaxRuns:  "at most %v times",
+	Splay:    "delayed by up to %v",
+	Location: "%v in %v time",
+
+	Union:     "%v; and %v",
+	Intersect: "%v, only when also %v",
+	Except:    "%v, except %v",
+	Calendar:  "%v, except when excluded by its calendar",
+}
+
+var englishOrdinals = [...]string{"", "first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth", "tenth"}
+
+func englishOrdinal(n int) string {
+	if n > 0 && n < len(englishOrdinals) {
+		return englishOrdinals[n]
+	}
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprint(n, suffix)
+}
+
+func englishList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " and " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+}
+
+//Describe returns an English description of s, such as "At 09:30 on every weekday in March".
+//Schedules that are not from this package are described by their expressions.
+func Describe(s Schedule) string {
+	return DescribeIn(s, English)
+}
+
+//DescribeIn is like Describe but builds the description from l.
+func DescribeIn(s Schedule, l *Locale) string {
+	return capitalize(describe(s, l))
+}
+
+func capitalize(value string) string {
+	r, size := utf8.DecodeRuneInString(value)
+	return string(unicode.ToUpper(r)) + value[size:]
+}
+
+func describe(s Schedule, l *Locale) string {
+	switch s := s.(type) {
+	case *schedule:
+		return describeSchedule(s, l)
+	case IntervalSchedule:
+		return describeEvery(time.Duration(s), l)
+	case *AnchoredIntervalSchedule:
+		if s.Offset == 0 {
+			return describeEvery(s.Interval, l)
+		}
+		return describeEvery(s.Interval, l) + " " + fmt.Sprintf(l.Offset, describeDuration(s.Offset, l))
+	case RebootSchedule:
+		return l.Reboot
+	case AtSchedule:
+		return fmt.Sprintf(l.Once, time.Time(s).Format(l.TimeLayout))
+	case *BoundedSchedule:
+		clauses := []string{describe(s.Schedule, l)}
+		if !s.Start.IsZero() {
+			clauses = append(clauses, fmt.Sprintf(l.Starting, s.Start.Format(l.TimeLayout)))
+		}
+		if !s.End.IsZero() {
+			clauses = append(clauses, fmt.Sprintf(l.Until, s.End.Format(l.TimeLayout)))
+		}
+		if s.MaxRuns > 0 {
+			clauses = append(clauses, fmt.Sprintf(l.MaxRuns, s.MaxRuns))
+		}
+		return strings.Join(clauses, " ")
+	case *SplaySchedule:
+		return describe(s.Schedule, l) + " " + fmt.Sprintf(l.Splay, describeDuration(s.Window, l))
+	case UnionSchedule:
+		return describeAll(l.Union, s, l)
+	case IntersectSchedule:
+		return describeAll(l.Intersect, s, l)
+	case *ExceptSchedule:
+		return describeAll(l.Except, []Schedule{s.Base, s.Exclude}, l)
+	case *CalendarSchedule:
+		return fmt.Sprintf(l.Calendar, describe(s.Schedule, l))
+	case *LocationSchedule:
+		return fmt.Sprintf(l.Location, describe(s.Schedule, l), s.Location)
+	case Expr:
+		return describe(s.Schedule, l)
+	}
+	return s.Expression()
+}
+
+//describeAll folds the descriptions of schedules from left to right with format.
+func describeAll(format string, schedules []Schedule, l *Locale) string {
+	result := ""
+	for i, schedule := range schedules {
+		if i == 0 {
+			result = describe(schedule, l)
+			continue
+		}
+		result = fmt.Sprintf(format, result, describe(schedule, l))
+	}
+	return result
+}
+
+func describeEvery(d time.Duration, l *Locale) string {
+	quantities := durationQuantities(d, l)
+	if len(quantities) == 1 && quantities[0].count == 1 {
+		return fmt.Sprintf(l.Every, quantities[0].unit.One)
+	}
+	if len(quantities) == 1 {
+		return fmt.Sprintf(l.EveryN, quantities[0].count, quantities[0].unit.Many)
+	}
+	return fmt.Sprintf(l.Every, describeDuration(d, l))
+}
+
+func describeDuration(d time.Duration, l *Locale) string {
+	quantities := durationQuantities(d, l)
+	if len(quantities) == 0 {
+		return d.String()
+	}
+	items := make([]string, 0, len(quantities))
+	for _, q := range quantities {
+		items = append(items, fmt.Sprintf(l.Quantity, q.count, q.unit.of(q.count)))
+	}
+	return l.List(items)
+}
+
+type quantity struct {
+	count int
+	unit  Unit
+}
+
+//durationQuantities splits d into weeks, days, hours, minutes, and seconds.
+//It returns nothing if d is not a positive number of seconds.
+func durationQuantities(d time.Duration, l *Locale) []quantity {
+	if d <= 0 || d%time.Second != 0 {
+		return nil
+	}
+	result := []quantity{}
+	units := []struct {
+		length time.Duration
+		unit   Unit
+	}{
+		{7 * 24 * time.Hour, l.Week},
+		{24 * time.Hour, l.Day},
+		{time.Hour, l.Hour},
+		{time.Minute, l.Minute},
+		{time.Second, l.Second},
+	}
+	for _, u := range units {
+		if count := int(d / u.length); count > 0 {
+			result = append(result, quantity{count, u.unit})
+			d -= time.Duration(count) * u.length
+		}
+	}
+	return result
+}
+
+func describeSchedule(s *schedule, l *Locale) string {
+	clauses := []string{}
+	timeOfDay, isListed := describeTimeOfDay(s, l)
+	clauses = append(clauses, timeOfDay...)
+	days := describeDays(s, l)
+	if days == "" && isListed {
+		days = l.EveryDay
+	}
+	clauses = append(clauses, days, describeField(s.month, month, l), describeField(s.year, year, l))
+	nonEmpty := clauses[:0]
+	for _, clause := range clauses {
+		if clause != "" {
+			nonEmpty = append(nonEmpty, clause)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
+
+//describeTimeOfDay returns the clauses that describe the second, minute, and hour fields
+//of s, and whether they list times of day.
+func describeTimeOfDay(s *schedule, l *Locale) ([]string, bool) {
+	seconds, minutes, hours := fieldValues(s.second, second), fieldValues(s.minute, minute), fieldValues(s.hour, hour)
+	if count := len(seconds) * len(minutes) * len(hours); !isAny(s.second, second) && count <= maxListedTimes {
+		showSeconds := len(seconds) > 1 || seconds[0] != 0
+		times := make([]string, 0, count)
+		for _, h := range hours {
+			for _, m := range minutes {
+				for _, sec := range seconds {
+					t := fmt.Sprintf("%02d:%02d", h, m)
+					if showSeconds {
+						t += fmt.Sprintf(":%02d", sec)
+					}
+					times = append(times, t)
+				}
+			}
+		}
+		return []string{fmt.Sprintf(l.AtTimes, l.List(times))}, true
+	}
+
+	secondZero, minuteZero := isValue(s.second, 0), isValue(s.minute, 0)
+	clauses := []string{}
+	switch {
+	case isAny(s.second, second):
+		clauses = append(clauses, fmt.Sprintf(l.Every, l.Second.One))
+	case !secondZero:
+		clauses = append(clauses, describeField(s.second, second, l))
+	}
+	switch {
+	case isAny(s.minute, minute):
+		//"every second" and "every 5 seconds" already say that it is every minute.
+		if !isAny(s.second, second) && !hasStep(s.second) {
+			clauses = append(clauses, fmt.Sprintf(l.Every, l.Minute.One))
+		}
+	case minuteZero && secondZero:
+		//"every 2 hours" already says that it is on the hour.
+		if !hasStep(s.hour) {
+			clauses = append(clauses, fmt.Sprintf(l.Every, l.Hour.One))
+		}
+	default:
+		clauses = append(clauses, describeField(s.minute, minute, l))
+	}
+	clauses = append(clauses, describeField(s.hour, hour, l))
+	return clauses, false
+}
+
+//fieldValues returns the values of fn in the field fi.
+func fieldValues(fn fieldNexter, fi fieldIndex) []int {
+	result := []int{}
+	fr := fi.fieldRange()
+	for value := fr.min; value <= fr.max; value++ {
+		if fn == nil || contains(fn, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func isValue(fn fieldNexter, value int) bool {
+	vn, ok := fn.(valueNexter)
+	return ok && int(vn) == value
+}
+
+//isAny returns whether fn is every value of the field fi.
+func isAny(fn fieldNexter, fi fieldIndex) bool {
+	switch fn := fn.(type) {
+	case nil:
+		return true
+	case *anyNexter:
+		return true
+	case *rangeNexter:
+		fr := fi.fieldRange()
+		return fn.min == fr.min && fn.max == fr.max
+	}
+	return false
+}
+
+func hasStep(fn fieldNexter) bool {
+	for _, part := range flattenNexter(fn) {
+		if _, ok := part.(*rangeDivNexter); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenNexter(fn fieldNexter) []fieldNexter {
+	if mn, ok := fn.(multiNexter); ok {
+		return mn
+	}
+	return []fieldNexter{fn}
+}
+
+func flattenDateNexter(dfn dateFieldNexter) []dateFieldNexter {
+	if mdn, ok := dfn.(multiDateFieldNexter); ok {
+		return mdn
+	}
+	return []dateFieldNexter{dfn}
+}
+
+//describeField returns a clause for fn in the field fi, or "" if it is every value.
+func describeField(fn fieldNexter, fi fieldIndex, l *Locale) string {
+	if isAny(fn, fi) {
+		return ""
+	}
+	items, steps, count := describeFieldItems(fn, fi, l)
+	clauses := []string{}
+	if len(items) > 0 {
+		clauses = append(clauses, fmt.Sprintf(fieldFormat(fi, l), fieldUnit(fi, l).of(count), l.List(items)))
+	}
+	clauses = append(clauses, steps...)
+	return l.List(clauses)
+}
+
+//describeFieldItems returns the values and ranges of fn, its steps, and the number of
+//values in its values and ranges.
+func describeFieldItems(fn fieldNexter, fi fieldIndex, l *Locale) ([]string, []string, int) {
+	items, steps, count := []string{}, []string{}, 0
+	fr := fi.fieldRange()
+	for _, part := range flattenNexter(fn) {
+		switch part := part.(type) {
+		case valueNexter:
+			items = append(items, valueName(int(part), fi, l))
+			count++
+		case *rangeNexter:
+			items = append(items, fmt.Sprintf(l.Range, valueName(part.min, fi, l), valueName(part.max, fi, l)))
+			count += 2
+		case *anyNexter:
+			items = append(items, fmt.Sprintf(l.Range, valueName(part.min, fi, l), valueName(part.max, fi, l)))
+			count += 2
+		case *wrapRangeNexter:
+			items = append(items, fmt.Sprintf(l.Range, valueName(part.min, fi, l), valueName(part.max, fi, l)))
+			count += 2
+		case *rangeDivNexter:
+			unit := fieldUnit(fi, l)
+			switch {
+			case part.inc > part.reach():
+				//a step past the end of the range only gives min.
+				items = append(items, valueName(part.min, fi, l))
+				count++
+			case part.min == fr.min && part.max == fr.max && part.inc == 1:
+				steps = append(steps, fmt.Sprintf(l.Every, unit.One))
+			case part.min == fr.min && part.max == fr.max:
+				steps = append(steps, fmt.Sprintf(l.EveryN, part.inc, unit.Many))
+			default:
+				steps = append(steps, fmt.Sprintf(l.EveryNFrom, part.inc, unit.Many, valueName(part.min, fi, l), valueName(part.max, fi, l)))
+			}
+		default:
+			items = append(items, part.String())
+			count++
+		}
+	}
+	return items, steps, count
+}
+
+func valueName(value int, fi fieldIndex, l *Locale) string {
+	switch {
+	case fi == month && value >= MinMonth && value <= MaxMonth:
+		return l.Months[value-MinMonth]
+	case fi == dow && value >= MinDow && value <= MaxDow:
+		return l.Weekdays[value]
+	}
+	return fmt.Sprint(value)
+}
+
+func fieldFormat(fi fieldIndex, l *Locale) string {
+	switch fi {
+	case second:
+		return l.AtSeconds
+	case minute:
+		return l.AtMinutes
+	case hour:
+		return l.DuringHours
+	case dom:
+		return l.OnDaysOfMonth
+	case month:
+		return l.InMonths
+	case dow:
+		return l.OnWeekdays
+	}
+	return l.InYears
+}
+
+func fieldUnit(fi fieldIndex, l *Locale) Unit {
+	switch fi {
+	case second:
+		return l.Second
+	case minute:
+		return l.Minute
+	case hour:
+		return l.Hour
+	case dom, dow:
+		return l.Day
+	case month:
+		return l.Month
+	}
+	return l.Year
+}
+
+//describeDays returns a clause for the day of month and day of week fields of s, or ""
+//if they match every day.
+func describeDays(s *schedule, l *Locale) string {
+	domClause, dowClause := describeDom(s.dom, l), describeDow(s.dow, l)
+	switch {
+	case domClause == "":
+		return dowClause
+	case dowClause == "":
+		return domClause
+	case s.daysOr:
+		return fmt.Sprintf(l.DaysOr, domClause, dowClause)
+	}
+	return fmt.Sprintf(l.DaysAnd, domClause, dowClause)
+}
+
+func describeDom(dfn dateFieldNexter, l *Locale) string {
+	if dfn == nil {
+		return ""
+	}
+	clauses, plain := []string{}, multiNexter{}
+	for _, part := range flattenDateNexter(dfn) {
+		domPart, ok := part.(*domFieldNexter)
+		switch {
+		case !ok:
+			clauses = append(clauses, part.String())
+		case domPart.isLast && domPart.isWeekday:
+			clauses = append(clauses, l.LastWeekdayOfMonth)
+		case domPart.isLast && domPart.offset == 0:
+			clauses = append(clauses, l.LastDayOfMonth)
+		case domPart.isLast:
+			clauses = append(clauses, fmt.Sprintf(l.NthToLastDayOfMonth, l.Ordinal(domPart.offset+1)))
+		case domPart.isWeekday:
+			clauses = append(clauses, fmt.Sprintf(l.NearestWeekday, domPart.fieldNexter))
+		default:
+			plain = append(plain, flattenNexter(domPart.fieldNexter)...)
+		}
+	}
+	if len(plain) > 0 {
+		if len(clauses) == 0 && len(plain) == 1 && isAny(plain[0], dom) {
+			return ""
+		}
+		clauses = append([]string{describeField(plain, dom, l)}, clauses...)
+	}
+	return l.List(clauses)
+}
+
+func describeDow(dfn dateFieldNexter, l *Locale) string {
+	if dfn == nil {
+		return ""
+	}
+	clauses, plain := []string{}, multiNexter{}
+	for _, part := range flattenDateNexter(dfn) {
+		dowPart, ok := part.(*dowFieldNexter)
+		if !ok {
+			clauses = append(clauses, part.String())
+			continue
+		}
+		items, _, _ := describeFieldItems(dowPart.fieldNexter, dow, l)
+		weekdays := l.List(items)
+		switch {
+		case dowPart.isLast || (dowPart.fromEnd && dowPart.number == 1):
+			clauses = append(clauses, fmt.Sprintf(l.LastWeekday, weekdays))
+		case dowPart.fromEnd:
+			clauses = append(clauses, fmt.Sprintf(l.NthToLastWeekday, l.Ordinal(dowPart.number), weekdays))
+		case dowPart.number != invalidValue:
+			clauses = append(clauses, fmt.Sprintf(l.NthWeekday, l.Ordinal(dowPart.number), weekdays))
+		default:
+			plain = append(plain, flattenNexter(dowPart.fieldNexter)...)
+		}
+	}
+	if len(plain) > 0 {
+		clause := ""
+		values := fieldValues(plain, dow)
+		switch {
+		case len(values) == MaxDow-MinDow+1:
+		case equalInts(values, []int{1, 2, 3, 4, 5}):
+			clause = l.EveryWeekday
+		case equalInts(values, []int{0, 6}):
+			clause = l.EveryWeekendDay
+		default:
+			clause = describeField(plain, dow, l)
+		}
+		if clause != "" {
+			clauses = append([]string{clause}, clauses...)
+		}
+	}
+	return l.List(clauses)
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package sched
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     string
+	}{
+		{"0 30 9 * 3 1-5", "At 09:30 on every weekday in March"},
+		{"0 0 0 * * 5L", "At 00:00 on the last Friday of each month"},
+		{"0 0 0 L * *", "At 00:00 on the last day of each month"},
+		{"0 0 0 L-2 * *", "At 00:00 on the third to last day of each month"},
+		{"0 0 0 LW * *", "At 00:00 on the last weekday of each month"},
+		{"0 0 12 15W * *", "At 12:00 on the weekday nearest day 15 of each month"},
+		{"0 0 12 * * 2#3", "At 12:00 on the third Tuesday of each month"},
+		{"0 0 12 * * 5#-2", "At 12:00 on the second to last Friday of each month"},
+		{"0 0 12 * * 5#-1", "At 12:00 on the last Friday of each month"},
+		{"0 */15 9-17 * * 1-5", "Every 15 minutes during hours 9 through 17 on every weekday"},
+		{"*/5 * * * * *", "Every 5 seconds"},
+		{"5/15 * * * * *", "Every 15 seconds from 5 through 59"},
+		{"15,45 * * * * *", "At seconds 15 and 45 every minute"},
+		{"* 5 * * * *", "Every second at minute 5"},
+		{"0 5 * * * *", "At minute 5"},
+		{"0 0 */2 * * *", "Every 2 hours"},
+		{"0 0 9-17 * * *", "Every hour during hours 9 through 17"},
+		{"0 0 22-2 * * *", "Every hour during hours 22 through 2"},
+		{"0 0 9,17 * * *", "At 09:00 and 17:00 every day"},
+		{"30 0 9 * * *", "At 09:00:30 every day"},
+		{"0 0 22-2/2 * * *", "At 00:00, 02:00, and 22:00 every day"},
+		{"0 */90 * * * *", "At minute 0"},
+		{"0 0 */25 * * *", "At 00:00 every day"},
+		{"0 0 0 */40 * *", "At 00:00 on day 1 of each month"},
+		{"0 10/45 * * * *", "Every 45 minutes from 10 through 59"},
+		{"0 0 22-2/6 * * *", "At 22:00 every day"},
+		{"0 0 0 1,15 * MON", "At 00:00 on days 1 and 15 of each month or on Monday"},
+		{"0 0 0 * * 0,6", "At 00:00 on weekends"},
+		{"0 0 0 * * MON,WED,FRI", "At 00:00 on Monday, Wednesday, and Friday"},
+		{"0 0 0 * 3-5 *", "At 00:00 every day in March through May"},
+		{"0 0 0 1 1 * 2027-2030", "At 00:00 on day 1 of each month in January in 2027 through 2030"},
+		{Hourly, "Every hour"},
+		{Weekly, "At 00:00 on Sunday"},
+		{Quarterly, "At 00:00 on day 1 of each month every 3 months"},
+		{"@every 1h", "Every hour"},
+		{"@every 90m", "Every 1 hour and 30 minutes"},
+		{"@every 2w", "Every 2 weeks"},
+		{"@every 15m from 00:07", "Every 15 minutes offset by 7 minutes"},
+		{Reboot, "At startup"},
+		{"@at 2026-12-01T09:00:00Z", "Once at 2026-12-01 09:00:00 UTC"},
+		{"0 0 9 * * * ~10m", "At 09:00 every day delayed by up to 10 minutes"},
+		{"0 30 9 * * 1-5 [2026-01-01T00:00:00Z,) x3", "At 09:30 on every weekday starting 2026-01-01 00:00:00 UTC at most 3 times"},
+		{"@daily; @hourly", "At 00:00 every day; and every hour"},
+		{"0 0 9 * * * EXCEPT 0 0 9 * * SAT,SUN", "At 09:00 every day, except at 09:00 on weekends"},
+		{"*/10 * * * * * AND 0 * * * * *", "Every 10 seconds, only when also every minute"},
+	}
+	for _, test := range tests {
+		result := Describe(MustParse(test.expression))
+		if result != test.result {
+			t.Errorf("Describe(%v) = %v WANT %v", test.expression, result, test.result)
+		}
+	}
+}
+
+func TestDescribe_calendar(t *testing.T) {
+	s := NewCalendarSchedule(MustParse(Daily), NewWeeklyCalendar(time.Sunday))
+	want := "At 00:00 every day, except when excluded by its calendar"
+	if result := Describe(s); result != want {
+		t.Errorf("Describe(%v) = %v WANT %v", s, result, want)
+	}
+}
+
+func TestDescribe_location(t *testing.T) {
+	s := NewLocationSchedule(MustParse(Daily), time.UTC)
+	want := "At 00:00 every day in UTC time"
+	if result := Describe(s); result != want {
+		t.Errorf("Describe(%v) = %v WANT %v", s, result, want)
+	}
+	if result := Describe(Expr{Schedule: s}); result != want {
+		t.Errorf("Describe(Expr{%v}) = %v WANT %v", s, result, want)
+	}
+}
+
+type expressionSchedule string
+
+func (s expressionSchedule) NextTime(from time.Time) (time.Time, bool) {
+	return from, false
+}
+
+func (s expressionSchedule) Expression() string {
+	return string(s)
+}
+
+func TestDescribe_otherSchedule(t *testing.T) {
+	s := expressionSchedule("FREQ=DAILY")
+	if result := Describe(s); result != "FREQ=DAILY" {
+		t.Errorf("Describe(%v) = %v WANT %v", s, result, "FREQ=DAILY")
+	}
+}
+
+func TestDescribeIn(t *testing.T) {
+	l := *English
+	l.Weekdays = [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"}
+	l.Ordinal = func(n int) string {
+		if n == 1 {
+			return "premier"
+		}
+		return englishOrdinal(n)
+	}
+	l.AtTimes = "à %v"
+	l.NthWeekday = "le %v %v de chaque mois"
+	tests := []struct {
+		expression string
+		result     string
+	}{
+		{"0 30 9 * * 1#1", "À 09:30 le premier lundi de chaque mois"},
+		{"0 30 9 * * 1#1,5#1", "À 09:30 le premier lundi de chaque mois and le premier vendredi de chaque mois"},
+	}
+	for _, test := range tests {
+		result := DescribeIn(MustParse(test.expression), &l)
+		if result != test.result {
+			t.Errorf("DescribeIn(%v) = %v WANT %v", test.expression, result, test.result)
+		}
+	}
+}
+
+func TestEnglishOrdinal(t *testing.T) {
+	tests := []struct {
+		n      int
+		result string
+	}{
+		{1, "first"},
+		{10, "tenth"},
+		{11, "11th"},
+		{12, "12th"},
+		{13, "13th"},
+		{21, "21st"},
+		{22, "22nd"},
+		{23, "23rd"},
+		{31, "31st"},
+	}
+	for _, test := range tests {
+		if result := englishOrdinal(test.n); result != test.result {
+			t.Errorf("englishOrdinal(%v) = %v WANT %v", test.n, result, test.result)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package sched
+
+import (
+	"fmt"
+	"strings"
+)
+
+//DayCombination determines how the day of month and day of week fields combine
+//when deciding whether a day matches.
+type DayCombination int
+
+const (
+	//DaysOr is the Vixie cron behavior. If both date fields are restricted, that is
+	//neither is Asterisk or Question, a day matches when either field matches it.
+	//Otherwise only the restricted field, if any, must match.
+	DaysOr DayCombination = iota
+
+	//DaysAnd matches a day only when both date fields match it.
+	DaysAnd
+
+	//DaysQuartz requires exactly one of the date fields to be Question, which is then
+	//ignored, and the other field must match.
+	DaysQuartz
+)
+
+//Dialect describes the grammar of the expressions a Parser accepts.
+//Expressions of every Dialect are normalized to the seven fields of Standard.
+type Dialect struct {
+	//Seconds is whether expressions include a leading second field.
+	Seconds bool
+
+	//Year is whether expressions include a trailing year field.
+	Year bool
+
+	//OptionalSeconds is whether the second field may be omitted, in which case it is 0.
+	OptionalSeconds bool
+
+	//OptionalYear is whether the year field may be omitted, in which case it is Asterisk.
+	//When both optional fields may be omitted, the year field is omitted first.
+	OptionalYear bool
+
+	//RequireQuestion is whether exactly one of the day of month and day of week
+	//fields must be Question.
+	RequireQuestion bool
+
+	//SundayIsOne is whether numeric day of week values are 1-7 starting with Sunday
+	//instead of 0-6.
+	SundayIsOne bool
+
+	//Directives is whether directives such as Hourly and Every are accepted.
+	Directives bool
+
+	//Days is how the day of month and day of week fields combine.
+	Days DayCombination
+}
+
+var (
+	//Standard accepts directives and 5, 6, or 7 fields. 5 fields start with minutes
+	//and have a second of 0. 6 fields start with seconds. 7 fields end with a year.
+	Standard = Dialect{
+		Seconds:         true,
+		Year:            true,
+		OptionalSeconds: true,
+		OptionalYear:    true,
+		Directives:      true,
+	}
+
+	//Vixie accepts directives and the 5 POSIX fields: minute, hour, day of month,
+	//month, and day of week.
+	Vixie = Dialect{
+		Directives: true,
+	}
+
+	//Quartz accepts 6 or 7 fields starting with seconds and optionally ending with a year.
+	//Days of week are 1-7 starting with Sunday, and one of the date fields must be Question.
+	Quartz = Dialect{
+		Seconds:         true,
+		Year:            true,
+		OptionalYear:    true,
+		RequireQuestion: true,
+		SundayIsOne:     true,
+		Days:            DaysQuartz,
+	}
+
+	//EventBridge accepts the 6 fields of AWS EventBridge cron expressions: minute, hour,
+	//day of month, month, day of week, and year.
+	//Days of week are 1-7 starting with Sunday, and one of the date fields must be Question.
+	EventBridge = Dialect{
+		Year:            true,
+		RequireQuestion: true,
+		SundayIsOne:     true,
+		Days:            DaysQuartz,
+	}
+)
+
+func (d *Dialect) fieldCount() int {
+	count := int(fieldCount) - 2
+	if d.Seconds {
+		count++
+	}
+	if d.Year {
+		count++
+	}
+	return count
+}
+
+//fieldCounts returns the allowed numbers of fields in ascending order.
+func (d *Dialect) fieldCounts() []int {
+	result := []int{}
+	if d.Directives {
+		result = append(result, 1, 2)
+	}
+	count := d.fieldCount()
+	optional := 0
+	if d.Seconds && d.OptionalSeconds {
+		optional++
+	}
+	if d.Year && d.OptionalYear {
+		optional++
+	}
+	for i := count - optional; i <= count; i++ {
+		result = append(result, i)
+	}
+	return result
+}
+
+func (d *Dialect) validateNumberOfFields(fields []string) (int, error) {
+	count := len(fields)
+	counts := d.fieldCounts()
+	for _, c := range counts {
+		if c == count {
+			return count, nil
+		}
+	}
+	return 0, newExprError(KindFieldCount, "number of fields must be %v", joinCounts(counts))
+}
+
+func joinCounts(counts []int) string {
+	values := make([]string, 0, len(counts))
+	for _, c := range counts {
+		values = append(values, fmt.Sprint(c))
+	}
+	if len(values) <= 2 {
+		return strings.Join(values, " or ")
+	}
+	return strings.Join(values[:len(values)-1], ", ") + ", or " + values[len(values)-1]
+}
+
+//normalizeFields returns the seven Standard fields from the fields of an expression in d.
+//fields must have already been validated with validateNumberOfFields.
+func (d *Dialect) normalizeFields(fields []string) []string {
+	omitSeconds, omitYear := d.omittedFields(len(fields))
+	result := make([]string, 0, fieldCount)
+	if omitSeconds {
+		result = append(result, fmt.Sprint(MinSecond))
+	}
+	result = append(result, fields...)
+	if omitYear {
+		result = append(result, Asterisk)
+	}
+	return result
+}
+
+//omittedFields returns whether the seconds and year fields are omitted from an expression
+//in d with count fields.
+func (d *Dialect) omittedFields(count int) (seconds, year bool) {
+	omitted := d.fieldCount() - count
+	year = !d.Year || (d.OptionalYear && omitted > 0)
+	if d.Year && year {
+		omitted--
+	}
+	seconds = !d.Seconds || (d.OptionalSeconds && omitted > 0)
+	return
+}
+
+//validateQuestion checks the date fields of the normalized fields against RequireQuestion
+//and DaysQuartz.
+func (d *Dialect) validateQuestion(fields []string) error {
+	if !d.RequireQuestion && d.Days != DaysQuartz {
+		return nil
+	}
+	domQuestion, dowQuestion := fields[dom] == Question, fields[dow] == Question
+	if domQuestion == dowQuestion {
+		return newExprError(KindQuestion, "exactly one of the %v and %v fields must be %q", dom, dow, Question)
+	}
+	return nil
+}
+
+//daysOr returns whether the date fields of the normalized fields match a day when
+//either one of them does.
+func (d *Dialect) daysOr(fields []string) bool {
+	return d.Days == DaysOr && !isAnyField(fields[dom]) && !isAnyField(fields[dow])
+}
+
+func isAnyField(field string) bool {
+	return field == Asterisk || field == Question
+}
+
+//dowRangeString returns the range of the day of week field as it is written in d.
+func (d *Dialect) dowRangeString() string {
+	if d.SundayIsOne {
+		return fmt.Sprintf("%v%v%v", MinDow+1, Hyphen, MaxDow+1)
+	}
+	return dow.rangeString()
+}
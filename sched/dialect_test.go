@@ -0,0 +1,156 @@
+package sched
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDialect_validateNumberOfFields(t *testing.T) {
+	errString := "number of fields must be 1, 2, 5, 6, or 7"
+	tests := []struct {
+		fields []string
+		count  int
+		err    string
+	}{
+		{nil, 0, errString},
+		{[]string{}, 0, errString},
+		{make([]string, 1), 1, ""},
+		{make([]string, 2), 2, ""},
+		{make([]string, 3), 0, errString},
+		{make([]string, 4), 0, errString},
+		{make([]string, 5), 5, ""},
+		{make([]string, 6), 6, ""},
+		{make([]string, 7), 7, ""},
+		{make([]string, 8), 0, errString},
+	}
+	for _, test := range tests {
+		count, err := Standard.validateNumberOfFields(test.fields)
+		if (err != nil || test.err != "") && err.Error() != test.err {
+			t.Errorf("validateNumberOfFields(%v) error = %v WANT %v", test.fields, err, test.err)
+		}
+		if count != test.count {
+			t.Errorf("validateNumberOfFields(%v) count = %v WANT %v", test.fields, count, test.count)
+		}
+	}
+}
+
+func TestDialect_fieldCounts(t *testing.T) {
+	tests := []struct {
+		d      Dialect
+		result []int
+	}{
+		{Standard, []int{1, 2, 5, 6, 7}},
+		{Vixie, []int{1, 2, 5}},
+		{Quartz, []int{6, 7}},
+		{EventBridge, []int{6}},
+		{Dialect{Seconds: true, OptionalSeconds: true}, []int{5, 6}},
+	}
+	for _, test := range tests {
+		if result := test.d.fieldCounts(); !reflect.DeepEqual(result, test.result) {
+			t.Errorf("%+v.fieldCounts() = %v WANT %v", test.d, result, test.result)
+		}
+	}
+}
+
+func TestJoinCounts(t *testing.T) {
+	tests := []struct {
+		counts []int
+		result string
+	}{
+		{[]int{6}, "6"},
+		{[]int{6, 7}, "6 or 7"},
+		{[]int{1, 2, 5}, "1, 2, or 5"},
+	}
+	for _, test := range tests {
+		if result := joinCounts(test.counts); result != test.result {
+			t.Errorf("joinCounts(%v) = %v WANT %v", test.counts, result, test.result)
+		}
+	}
+}
+
+func TestDialect_normalizeFields(t *testing.T) {
+	tests := []struct {
+		d      Dialect
+		fields []string
+		result []string
+	}{
+		{Standard, Fields("a b c d e"), Fields("0 a b c d e *")},
+		{Standard, Fields("a b c d e f"), Fields("a b c d e f *")},
+		{Standard, Fields("a b c d e f g"), Fields("a b c d e f g")},
+		{Vixie, Fields("a b c d e"), Fields("0 a b c d e *")},
+		{Quartz, Fields("a b c d e f"), Fields("a b c d e f *")},
+		{Quartz, Fields("a b c d e f g"), Fields("a b c d e f g")},
+		{EventBridge, Fields("a b c d e f"), Fields("0 a b c d e f")},
+		{Dialect{Seconds: true, OptionalSeconds: true}, Fields("a b c d e"), Fields("0 a b c d e *")},
+		{Dialect{Seconds: true, OptionalSeconds: true}, Fields("a b c d e f"), Fields("a b c d e f *")},
+	}
+	for _, test := range tests {
+		if result := test.d.normalizeFields(test.fields); !reflect.DeepEqual(result, test.result) {
+			t.Errorf("%+v.normalizeFields(%v) = %v WANT %v", test.d, test.fields, result, test.result)
+		}
+	}
+}
+
+func TestParser_Parse_dialects(t *testing.T) {
+	tests := []struct {
+		d          Dialect
+		expression string
+		result     string
+		err        string
+	}{
+		{Standard, "0 12 * * 1", "0 0 12 * * 1 *", ""},
+		{Standard, "0 0 12 ? * 1", "0 0 12 * * 1 *", ""},
+		{Vixie, "0 12 * * 1", "0 0 12 * * 1 *", ""},
+		{Vixie, Hourly, "0 0 * * * * *", ""},
+		{Vixie, "0 0 12 * * 1", "", "number of fields must be 1, 2, or 5"},
+		{Quartz, "0 0 12 ? * 2", "0 0 12 * * 1 *", ""},
+		{Quartz, "0 0 12 ? * 1,7", "0 0 12 * * 0,6 *", ""},
+		{Quartz, "0 0 12 ? * MON-FRI", "0 0 12 * * 1-5 *", ""},
+		{Quartz, "0 0 12 ? * *", "0 0 12 * * * *", ""},
+		{Quartz, "0 15 10 15 * ? 2030", "0 15 10 15 * * 2030", ""},
+		{Quartz, "0 0 12 ? * 0", "", `day of week field: section before modifiers "0" not in range`},
+		{Quartz, "0 0 12 * * 2", "", `exactly one of the day of month and day of week fields must be "?"`},
+		{Quartz, "0 0 12 ? * ?", "", `exactly one of the day of month and day of week fields must be "?"`},
+		{Quartz, Hourly, "", "number of fields must be 6 or 7"},
+		{EventBridge, "0 10 * * ? *", "0 0 10 * * * *", ""},
+		{EventBridge, "15 12 ? * 2-6 2030", "0 15 12 * * 1-5 2030", ""},
+		{EventBridge, "0 18 ? * MON-FRI *", "0 0 18 * * 1-5 *", ""},
+		{EventBridge, "0 18 * * MON-FRI *", "", `exactly one of the day of month and day of week fields must be "?"`},
+	}
+	for _, test := range tests {
+		d := test.d
+		result, err := (&Parser{Dialect: &d}).Parse(test.expression)
+		if err != nil {
+			if test.err == "" || err.(*ParseError).Description != test.err {
+				t.Errorf("Parse(%q) error = %v WANT %v", test.expression, err, test.err)
+			}
+			continue
+		}
+		if test.err != "" {
+			t.Errorf("Parse(%q) error = nil WANT %v", test.expression, test.err)
+			continue
+		}
+		if expression := result.Expression(); expression != test.result {
+			t.Errorf("Parse(%q).Expression() = %v WANT %v", test.expression, expression, test.result)
+		}
+	}
+}
+
+func TestDialect_daysOr(t *testing.T) {
+	tests := []struct {
+		d      Dialect
+		fields []string
+		result bool
+	}{
+		{Standard, Fields("0 0 0 1 * 1 *"), true},
+		{Standard, Fields("0 0 0 * * 1 *"), false},
+		{Standard, Fields("0 0 0 1 * ? *"), false},
+		{Dialect{Days: DaysAnd}, Fields("0 0 0 1 * 1 *"), false},
+		{Quartz, Fields("0 0 0 1 * ? *"), false},
+	}
+	for _, test := range tests {
+		if result := test.d.daysOr(test.fields); result != test.result {
+			t.Errorf("%+v.daysOr(%v) = %v WANT %v", test.d, test.fields, result, test.result)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package sched
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//ErrorKind classifies the mistake that a ParseError reports.
+//ErrorKinds are errors so that errors.Is(err, KindNotInRange) reports whether err is a
+//ParseError of that kind.
+type ErrorKind int
+
+const (
+	//KindSyntax is a mistake that no other ErrorKind describes.
+	KindSyntax ErrorKind = iota
+
+	//KindFieldCount is an expression with the wrong number of fields.
+	KindFieldCount
+
+	//KindEmpty is a field, part, or value that is missing.
+	KindEmpty
+
+	//KindNotInteger is a value that is not a decimal integer or name.
+	KindNotInteger
+
+	//KindNotInRange is a value outside of the range of its field.
+	KindNotInRange
+
+	//KindRange is a range whose sides are in the wrong order or that is not allowed.
+	KindRange
+
+	//KindStep is an invalid step value, as after Slash.
+	KindStep
+
+	//KindModifier is an unknown or invalid use of a modifier, such as Last or Hash.
+	KindModifier
+
+	//KindQuestion is an invalid use of Question in the date fields.
+	KindQuestion
+
+	//KindDirective is an unknown directive.
+	KindDirective
+
+	//KindInterval is an invalid Every expression.
+	KindInterval
+
+	//KindAt is an invalid At expression.
+	KindAt
+
+	//KindBounds is an invalid window or maximum number of runs.
+	KindBounds
+
+	//KindSplay is an invalid splay window.
+	KindSplay
+
+	//KindComposite is an unbalanced brace or a composite separator without expressions on
+	//both sides.
+	KindComposite
+
+	//KindTimeZone is a TimeZone field whose location cannot be loaded.
+	KindTimeZone
+)
+
+var errorKindNames = [...]string{
+	"syntax",
+	"field count",
+	"empty",
+	"not an integer",
+	"not in range",
+	"range",
+	"step",
+	"modifier",
+	"question",
+	"directive",
+	"interval",
+	"at",
+	"bounds",
+	"splay",
+	"composite",
+	"time zone",
+}
+
+func (k ErrorKind) String() string {
+	if k >= 0 && int(k) < len(errorKindNames) {
+		return errorKindNames[k]
+	}
+	return fmt.Sprintf("ErrorKind(%v)", int(k))
+}
+
+func (k ErrorKind) Error() string {
+	return "sched: " + k.String() + " error"
+}
+
+//ParseError is the error that Parse returns for an invalid expression.
+//Field, Part, and Offset are -1 when the error is not in a field, part, or known position.
+type ParseError struct {
+	Expression  string
+	Description string
+
+	Kind ErrorKind
+
+	//Field is the index of the field, from 0 for seconds to 6 for years, and FieldName is
+	//its name, such as "day of month".
+	Field     int
+	FieldName string
+
+	//Part is the index of the comma separated part of the field.
+	Part int
+
+	//Offset is the byte offset of Token in Expression.
+	Offset int
+	Token  string
+}
+
+func newParseError(exp, desc string) *ParseError {
+	return &ParseError{
+		Expression:  exp,
+		Description: desc,
+		Field:       -1,
+		Part:        -1,
+		Offset:      -1,
+	}
+}
+
+func (p *ParseError) Error() string {
+	return fmt.Sprintf("sched: could not parse %q: %v", p.Expression, p.Description)
+}
+
+//Is returns whether target is the Kind of p.
+func (p *ParseError) Is(target error) bool {
+	kind, ok := target.(ErrorKind)
+	return ok && kind == p.Kind
+}
+
+//Pretty returns the Error of p followed by the expression and a line of carets under
+//Token, such as:
+//
+//	sched: could not parse "0 70 * * * *": minute field: not in range
+//	  0 70 * * * *
+//	    ^^
+func (p *ParseError) Pretty() string {
+	return pretty(p.Error(), p.Expression, p.Offset, p.Token)
+}
+
+//pretty returns message followed by expression and a line of carets under token at offset.
+func pretty(message, expression string, offset int, token string) string {
+	if offset < 0 || offset > len(expression) {
+		return message
+	}
+	//tabs are kept so that the carets line up with the expression.
+	pad := strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return r
+		}
+		return ' '
+	}, expression[:offset])
+	carets := utf8.RuneCountInString(token)
+	if carets == 0 {
+		carets = 1
+	}
+	return fmt.Sprintf("%v\n  %v\n  %v%v", message, expression, pad, strings.Repeat("^", carets))
+}
+
+//exprError is an error in an expression that Parser.Parse turns into a ParseError.
+//offset is relative to the text that the error was found in, and is moved as the error is
+//returned through the texts that contain that text.
+//A warning exprError is only found when collecting and describes a valid expression.
+type exprError struct {
+	kind    ErrorKind
+	field   fieldIndex
+	part    int
+	offset  int
+	token   string
+	desc    string
+	warning bool
+}
+
+func newExprError(kind ErrorKind, format string, args ...interface{}) *exprError {
+	return &exprError{
+		kind:   kind,
+		field:  invalidValue,
+		part:   invalidValue,
+		offset: invalidValue,
+		desc:   fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *exprError) Error() string {
+	return e.desc
+}
+
+//asExprError returns err as an exprError, which is KindSyntax if err is not one.
+func asExprError(err error) *exprError {
+	var e *exprError
+	if errors.As(err, &e) {
+		return e
+	}
+	return newExprError(KindSyntax, "%v", err.Error())
+}
+
+//wrapExprError returns a copy of err with the description from format and args.
+func wrapExprError(err error, format string, args ...interface{}) *exprError {
+	result := *asExprError(err)
+	result.desc = fmt.Sprintf(format, args...)
+	return &result
+}
+
+//withToken returns a copy of e whose token is token at offset, which may be invalidValue
+//if it is not known.
+func (e *exprError) withToken(token string, offset int) *exprError {
+	result := *e
+	result.token, result.offset = token, offset
+	return &result
+}
+
+//shift returns a copy of e whose offset is moved by offset, as when the text e was found in
+//starts at offset in a containing text.
+func (e *exprError) shift(offset int) *exprError {
+	result := *e
+	if result.offset >= 0 {
+		result.offset += offset
+	}
+	return &result
+}
+
+//inPart returns a copy of e that is in the part at index of parts. The offset of e becomes
+//relative to the field that parts are split from.
+func (e *exprError) inPart(parts []string, index int) *exprError {
+	result := *e
+	result.part = index
+	part := parts[index]
+	if result.token == "" {
+		result.token, result.offset = part, 0
+	}
+	if result.offset < 0 || result.offset > len(part) {
+		result.offset = maxInt(strings.Index(part, result.token), 0)
+	}
+	for _, before := range parts[:index] {
+		result.offset += len(before) + len(Comma)
+	}
+	return &result
+}
+
+//locate sets the offset of e to the first field of expression that is its token, or else
+//the first occurrence of its token, if its offset is not known.
+func (e *exprError) locate(expression string) *exprError {
+	result := *e
+	if result.offset >= 0 || result.token == "" {
+		return &result
+	}
+	for _, span := range fieldSpans(expression) {
+		if expression[span[0]:span[1]] == result.token {
+			result.offset = span[0]
+			return &result
+		}
+	}
+	result.offset = strings.Index(expression, result.token)
+	return &result
+}
+
+//exprErrors is every error and warning found in an expression when collecting.
+type exprErrors []*exprError
+
+func (e exprErrors) Error() string {
+	descs := make([]string, 0, len(e))
+	for _, ee := range e {
+		descs = append(descs, ee.desc)
+	}
+	return strings.Join(descs, "; ")
+}
+
+//add returns e with err, which may be an exprErrors, appended to it.
+func (e exprErrors) add(err error) exprErrors {
+	var list exprErrors
+	if errors.As(err, &list) {
+		return append(e, list...)
+	}
+	return append(e, asExprError(err))
+}
+
+//mapExprErrors returns err with f applied to it, or to each of its exprErrors if it is an
+//exprErrors.
+func mapExprErrors(err error, f func(e *exprError) *exprError) error {
+	var list exprErrors
+	if !errors.As(err, &list) {
+		return f(asExprError(err))
+	}
+	result := make(exprErrors, 0, len(list))
+	for _, e := range list {
+		result = append(result, f(e))
+	}
+	return result
+}
+
+func (e *exprError) parseError(expression string) *ParseError {
+	e = e.locate(expression)
+	result := newParseError(expression, e.desc)
+	result.Kind = e.kind
+	if e.field >= 0 {
+		result.Field, result.FieldName = int(e.field), e.field.String()
+	}
+	result.Part = e.part
+	if e.offset >= 0 && e.offset <= len(expression) {
+		result.Offset, result.Token = e.offset, e.token
+	}
+	return result
+}
+
+//fieldSpans returns the start and end offsets in expression of each of Fields(expression).
+func fieldSpans(expression string) [][2]int {
+	result := [][2]int{}
+	first := len(expression) - len(strings.TrimLeft(expression, TrimCutset))
+	last := len(strings.TrimRight(expression, TrimCutset))
+	start := invalidValue
+	for i, r := range expression {
+		if i < first || i >= last {
+			continue
+		}
+		isSeparator := fieldSeparatorFunc(r)
+		if isSeparator && start >= 0 {
+			result = append(result, [2]int{start, i})
+			start = invalidValue
+		} else if !isSeparator && start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		result = append(result, [2]int{start, last})
+	}
+	return result
+}
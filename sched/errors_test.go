@@ -0,0 +1,118 @@
+package sched
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse_parseErrorFields(t *testing.T) {
+	tests := []struct {
+		expression string
+		kind       ErrorKind
+		field      int
+		fieldName  string
+		part       int
+		offset     int
+		token      string
+	}{
+		{"0 70 * * * *", KindNotInRange, 1, "minute", 0, 2, "70"},
+		{"0 70 * * *", KindNotInRange, 2, "hour", 0, 2, "70"},
+		{"0 0 5,a * * *", KindNotInteger, 2, "hour", 1, 6, "a"},
+		{"0 0 1-y * * *", KindNotInteger, 2, "hour", 0, 6, "y"},
+		{"0 0\t99 * * *", KindNotInRange, 2, "hour", 0, 4, "99"},
+		{"0 0 0 * * 1#9", KindModifier, 5, "day of week", 0, 11, "#9"},
+		{"0 0 0 * * 5Lx", KindModifier, 5, "day of week", 0, 12, "x"},
+		{"0 0 0 * * */0", KindStep, 5, "day of week", 0, 11, "/0"},
+		{"0 0 0 1 * MON-MON", KindRange, 5, "day of week", 0, 10, "MON-MON"},
+		{"0 0 H(1-x) * * *", KindNotInteger, 2, "hour", 0, 8, "x"},
+		{"0 0 * * *; 0 61 * * *", KindNotInRange, 2, "hour", 0, 13, "61"},
+		{"{0 0 * * *", KindComposite, -1, "", -1, 0, "{"},
+		{"0 0 * * *}", KindComposite, -1, "", -1, 9, "}"},
+		{"0 0 * * * AND", KindComposite, -1, "", -1, 10, "AND"},
+		{"0 0 * * * ~x", KindSplay, -1, "", -1, 10, "~x"},
+		{"0 0 * * * [a,b)", KindBounds, -1, "", -1, 10, "[a,b)"},
+		{" @FOO", KindDirective, -1, "", -1, 1, "@FOO"},
+		{"@every 1h from y", KindInterval, -1, "", -1, 15, "y"},
+		{"0 0 * *", KindFieldCount, -1, "", -1, -1, ""},
+		{"0 0 31 4 *", KindNotInRange, 3, "day of month", 0, 4, "31"},
+		{"0 0 30,31 2 *", KindNotInRange, 3, "day of month", -1, 4, "30,31"},
+		{"0 0 0 29 2 * 2025-2027", KindNotInRange, 3, "day of month", 0, 6, "29"},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.expression)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("Parse(%q) error = %v WANT *ParseError", test.expression, err)
+			continue
+		}
+		if pe.Kind != test.kind || pe.Field != test.field || pe.FieldName != test.fieldName || pe.Part != test.part {
+			t.Errorf("Parse(%q) error kind, field, part = %v, %v %q, %v WANT %v, %v %q, %v",
+				test.expression, pe.Kind, pe.Field, pe.FieldName, pe.Part, test.kind, test.field, test.fieldName, test.part,
+			)
+		}
+		if pe.Offset != test.offset || pe.Token != test.token {
+			t.Errorf("Parse(%q) error offset, token = %v, %q WANT %v, %q", test.expression, pe.Offset, pe.Token, test.offset, test.token)
+		}
+		if !errors.Is(err, test.kind) {
+			t.Errorf("errors.Is(Parse(%q), %v) = false WANT true", test.expression, test.kind)
+		}
+	}
+}
+
+func TestParseError_Pretty(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     string
+	}{
+		{"0 70 * * * *", "sched: could not parse \"0 70 * * * *\": minute field: not in range\n  0 70 * * * *\n    ^^"},
+		{"0 0\t99 * * *", "sched: could not parse \"0 0\\t99 * * *\": hour field: not in range\n  0 0\t99 * * *\n     \t^^"},
+		{"0 0 * *", `sched: could not parse "0 0 * *": number of fields must be 1, 2, 5, 6, or 7`},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.expression)
+		if result := err.(*ParseError).Pretty(); result != test.result {
+			t.Errorf("Pretty(%q) = %q WANT %q", test.expression, result, test.result)
+		}
+	}
+}
+
+func TestErrorKind_String(t *testing.T) {
+	tests := []struct {
+		kind   ErrorKind
+		result string
+	}{
+		{KindSyntax, "syntax"},
+		{KindNotInRange, "not in range"},
+		{KindComposite, "composite"},
+		{ErrorKind(-1), "ErrorKind(-1)"},
+	}
+	for _, test := range tests {
+		if result := test.kind.String(); result != test.result {
+			t.Errorf("ErrorKind(%d).String() = %v WANT %v", int(test.kind), result, test.result)
+		}
+	}
+}
+
+func TestFieldSpans(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     [][2]int
+	}{
+		{"", [][2]int{}},
+		{"a", [][2]int{{0, 1}}},
+		{" a \tbc ", [][2]int{{1, 2}, {4, 6}}},
+	}
+	for _, test := range tests {
+		result := fieldSpans(test.expression)
+		if len(result) != len(test.result) {
+			t.Errorf("fieldSpans(%q) = %v WANT %v", test.expression, result, test.result)
+			continue
+		}
+		for i := range result {
+			if result[i] != test.result[i] {
+				t.Errorf("fieldSpans(%q) = %v WANT %v", test.expression, result, test.result)
+				break
+			}
+		}
+	}
+}
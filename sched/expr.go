@@ -0,0 +1,102 @@
+package sched
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+//Expr is a Schedule that is encoded as its expression, so that invalid expressions are
+//rejected when a configuration file, request body, or database row is decoded.
+//The zero value has a nil Schedule and is encoded as an empty expression, or as NULL in a
+//database.
+//
+//Only Schedules whose expressions Parse accepts can be decoded again, so the schedules of the
+//rrule package, whose expressions have multiple lines, cannot be stored as Exprs.
+//
+//Expressions do not include the seed that Hashed values and splay offsets are derived from,
+//so Seed must be set before decoding for a splay schedule to keep its offset.
+type Expr struct {
+	Schedule
+
+	//Seed is the seed that expressions are parsed with, as with ParseWithSeed.
+	Seed string
+}
+
+//ParseExpr parses expression like ParseWithSeed.
+func ParseExpr(expression, seed string) (Expr, error) {
+	s, err := ParseWithSeed(expression, seed)
+	if err != nil {
+		return Expr{}, err
+	}
+	return Expr{Schedule: s, Seed: seed}, nil
+}
+
+//IsZero returns whether e has no Schedule.
+func (e Expr) IsZero() bool {
+	return e.Schedule == nil
+}
+
+func (e Expr) String() string {
+	if e.Schedule == nil {
+		return ""
+	}
+	return e.Schedule.Expression()
+}
+
+func (e Expr) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+//UnmarshalText parses text with the Seed of e. Empty text has a nil Schedule.
+func (e *Expr) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		e.Schedule = nil
+		return nil
+	}
+	result, err := ParseExpr(string(text), e.Seed)
+	if err != nil {
+		return err
+	}
+	*e = result
+	return nil
+}
+
+func (e Expr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+//UnmarshalJSON parses a JSON string like UnmarshalText. null has a nil Schedule.
+func (e *Expr) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		e.Schedule = nil
+		return nil
+	}
+	var expression string
+	if err := json.Unmarshal(data, &expression); err != nil {
+		return fmt.Errorf("sched: expression must be a string: %v", err)
+	}
+	return e.UnmarshalText([]byte(expression))
+}
+
+//Scan parses a string or []byte column value like UnmarshalText. NULL has a nil Schedule.
+func (e *Expr) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		e.Schedule = nil
+		return nil
+	case string:
+		return e.UnmarshalText([]byte(src))
+	case []byte:
+		return e.UnmarshalText(src)
+	}
+	return fmt.Errorf("sched: cannot scan %T into an Expr", src)
+}
+
+//Value returns the expression of e, or nil for the zero value.
+func (e Expr) Value() (driver.Value, error) {
+	if e.Schedule == nil {
+		return nil, nil
+	}
+	return e.Schedule.Expression(), nil
+}
@@ -0,0 +1,117 @@
+package sched
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExpr_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		text   string
+		result string
+		err    bool
+	}{
+		{"", "", false},
+		{"@daily", DailyFormat, false},
+		{"0 0 2 * * *", "0 0 2 * * * *", false},
+		{"0 0 2 * * * * *", "", true},
+		{"0 59-59/30 * * * *", "", true},
+		{"0 59/30 * * * * *", "0 59/30 * * * * *", false},
+	}
+	for _, test := range tests {
+		e := Expr{}
+		err := e.UnmarshalText([]byte(test.text))
+		if e.String() != test.result || (err != nil) != test.err {
+			t.Errorf("UnmarshalText(%q) = %q, %v WANT %q, %v", test.text, e.String(), err, test.result, test.err)
+		}
+	}
+}
+
+func TestExpr_JSON(t *testing.T) {
+	value := struct {
+		Schedule Expr  `json:"schedule"`
+		Optional *Expr `json:"optional"`
+	}{}
+	if err := json.Unmarshal([]byte(`{"schedule": "@hourly", "optional": null}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.Schedule.String() != HourlyFormat || value.Optional != nil {
+		t.Errorf("Unmarshal() = %v, %v WANT %v, nil", value.Schedule, value.Optional, HourlyFormat)
+	}
+	data, err := json.Marshal(value)
+	if want := `{"schedule":"0 0 * * * * *","optional":null}`; string(data) != want || err != nil {
+		t.Errorf("Marshal() = %s, %v WANT %s, nil", data, err, want)
+	}
+
+	err = json.Unmarshal([]byte(`{"schedule": "0 70 * * * *"}`), &value)
+	if pe := (*ParseError)(nil); !errors.As(err, &pe) || pe.FieldName != "minute" {
+		t.Errorf("Unmarshal() error = %v WANT a *ParseError for the minute field", err)
+	}
+	if err := json.Unmarshal([]byte(`{"schedule": 5}`), &value); err == nil {
+		t.Errorf("Unmarshal(5) error = nil WANT an error")
+	}
+	if err := json.Unmarshal([]byte(`{"schedule": null}`), &value); err != nil || !value.Schedule.IsZero() {
+		t.Errorf("Unmarshal(null) = %v, %v WANT the zero value", value.Schedule, err)
+	}
+
+	//a step from the end of a field is marshaled as text that unmarshals again.
+	if err := json.Unmarshal([]byte(`{"schedule": "0 0 23/5 * * *"}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = json.Marshal(value)
+	if err := json.Unmarshal(data, &value); err != nil || value.Schedule.String() != "0 0 23/5 * * * *" {
+		t.Errorf("Unmarshal(%s) = %v, %v WANT 0 0 23/5 * * * *, nil", data, value.Schedule, err)
+	}
+}
+
+func TestExpr_Scan(t *testing.T) {
+	tests := []struct {
+		src    interface{}
+		result string
+		err    bool
+	}{
+		{nil, "", false},
+		{"@daily", DailyFormat, false},
+		{[]byte("@hourly"), HourlyFormat, false},
+		{"0 70 * * * *", "", true},
+		{int64(5), "", true},
+	}
+	for _, test := range tests {
+		e := Expr{}
+		err := e.Scan(test.src)
+		if e.String() != test.result || (err != nil) != test.err {
+			t.Errorf("Scan(%v) = %q, %v WANT %q, %v", test.src, e.String(), err, test.result, test.err)
+		}
+	}
+}
+
+func TestExpr_Value(t *testing.T) {
+	if value, err := (Expr{}).Value(); value != nil || err != nil {
+		t.Errorf("Value() zero = %v, %v WANT <nil>, <nil>", value, err)
+	}
+	if value, err := (Expr{Schedule: MustParse(Daily)}).Value(); value != DailyFormat || err != nil {
+		t.Errorf("Value() = %v, %v WANT %v, <nil>", value, err, DailyFormat)
+	}
+}
+
+func TestExpr_Seed(t *testing.T) {
+	offsets := map[string]time.Duration{}
+	for _, seed := range []string{"a", "b"} {
+		e := Expr{Seed: seed}
+		if err := e.UnmarshalText([]byte("@hourly ~10m")); err != nil {
+			t.Fatal(err)
+		}
+		offsets[seed] = e.Schedule.(*SplaySchedule).Offset()
+
+		text, _ := e.MarshalText()
+		again := Expr{Seed: seed}
+		if err := again.Scan(text); err != nil || again.Schedule.(*SplaySchedule).Offset() != offsets[seed] {
+			t.Errorf("Scan(%s) with seed %q = %v, %v WANT offset %v", text, seed, again, err, offsets[seed])
+		}
+	}
+	if offsets["a"] == offsets["b"] {
+		t.Errorf("Offset() = %v for seeds a and b WANT different offsets", offsets["a"])
+	}
+}
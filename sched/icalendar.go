@@ -0,0 +1,153 @@
+package sched
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	icalDateLayout     = "20060102"
+	icalDateTimeLayout = "20060102T150405"
+)
+
+//LoadICalendar reads the iCalendar (RFC 5545) file at path with ParseICalendar.
+func LoadICalendar(path string) (Calendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseICalendar(f)
+}
+
+//ParseICalendar returns a Calendar that excludes the events in the iCalendar (RFC 5545)
+//data read from r.
+//All day events exclude whole calendar days in the location of the times they are given.
+//Other events exclude [DTSTART, DTEND), and events without a DTEND exclude nothing.
+//Only DTSTART and DTEND of each VEVENT are used, so recurrence rules are ignored.
+func ParseICalendar(r io.Reader) (Calendar, error) {
+	lines, err := unfoldICalendarLines(r)
+	if err != nil {
+		return nil, err
+	}
+	dates, intervals := DateCalendar{}, IntervalCalendar{}
+	var event map[string]string
+	for i, line := range lines {
+		switch {
+		case strings.EqualFold(line, "BEGIN:VEVENT"):
+			event = map[string]string{}
+		case strings.EqualFold(line, "END:VEVENT"):
+			if event == nil {
+				return nil, fmt.Errorf("sched: icalendar line %v: END:VEVENT without BEGIN:VEVENT", i+1)
+			}
+			if err := addICalendarEvent(event, dates, &intervals); err != nil {
+				return nil, fmt.Errorf("sched: icalendar line %v: %v", i+1, err.Error())
+			}
+			event = nil
+		case event != nil:
+			colonIndex := strings.Index(line, ":")
+			if colonIndex < 0 {
+				return nil, fmt.Errorf("sched: icalendar line %v: property must contain %q", i+1, ":")
+			}
+			event[line[:colonIndex]] = line[colonIndex+1:]
+		}
+	}
+	return MultiCalendar{dates, intervals}, nil
+}
+
+//unfoldICalendarLines returns the lines of r with continuation lines, which start with a
+//space or tab, joined to the lines before them.
+func unfoldICalendarLines(r io.Reader) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+//addICalendarEvent adds the times excluded by event, which maps property names with their
+//parameters to values, to dates or intervals.
+func addICalendarEvent(event map[string]string, dates DateCalendar, intervals *IntervalCalendar) error {
+	start, startIsDate, hasStart, err := findICalendarTime(event, "DTSTART")
+	if err != nil {
+		return err
+	}
+	if !hasStart {
+		return fmt.Errorf("VEVENT must have a DTSTART")
+	}
+	end, _, hasEnd, err := findICalendarTime(event, "DTEND")
+	if err != nil {
+		return err
+	}
+	if startIsDate {
+		if !hasEnd {
+			end = start.AddDate(0, 0, 1)
+		}
+		for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+			dates.Add(day.Year(), day.Month(), day.Day())
+		}
+		return nil
+	}
+	if hasEnd && start.Before(end) {
+		*intervals = append(*intervals, Interval{start, end})
+	}
+	return nil
+}
+
+//findICalendarTime finds and parses the property name in event, returning whether it is
+//a date without a time and whether it was found.
+func findICalendarTime(event map[string]string, name string) (time.Time, bool, bool, error) {
+	for property, value := range event {
+		parts := strings.Split(property, ";")
+		if !strings.EqualFold(parts[0], name) {
+			continue
+		}
+		t, isDate, err := parseICalendarTime(value, parts[1:])
+		if err != nil {
+			return t, isDate, true, fmt.Errorf("%v %v", name, err.Error())
+		}
+		return t, isDate, true, nil
+	}
+	return time.Time{}, false, false, nil
+}
+
+//parseICalendarTime parses value as a DATE or DATE-TIME with the parameters params.
+//Dates are returned in UTC, and DATE-TIMEs without a TZID parameter or "Z" suffix are in
+//time.Local.
+func parseICalendarTime(value string, params []string) (time.Time, bool, error) {
+	loc := time.Local
+	for _, param := range params {
+		keyValue := strings.SplitN(param, "=", 2)
+		if len(keyValue) == 2 && strings.EqualFold(keyValue[0], "TZID") {
+			var err error
+			if loc, err = time.LoadLocation(strings.Trim(keyValue[1], `"`)); err != nil {
+				return time.Time{}, false, err
+			}
+		}
+	}
+	if len(value) == len(icalDateLayout) {
+		t, err := time.ParseInLocation(icalDateLayout, value, time.UTC)
+		if err != nil {
+			return t, true, fmt.Errorf("value %q is not a valid date", value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		value, loc = strings.TrimSuffix(value, "Z"), time.UTC
+	}
+	t, err := time.ParseInLocation(icalDateTimeLayout, value, loc)
+	if err != nil {
+		return t, false, fmt.Errorf("value %q is not a valid date or date-time", value)
+	}
+	return t, false, nil
+}
@@ -0,0 +1,95 @@
+package sched
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testICalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Christmas\r\n" +
+	"DTSTART;VALUE=DATE:20161224\r\n" +
+	"DTEND;VALUE=DATE:20161227\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:New Year\r\n" +
+	"DTSTART;VALUE=DATE:20170101\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Maintenance\r\n" +
+	"DTSTART:20161228T020000Z\r\n" +
+	"DTEND:20161228T0\r\n" +
+	" 40000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=America/New_York:20161229T090000\r\n" +
+	"DTEND;TZID=America/New_York:20161229T100000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20161230T090000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICalendar(t *testing.T) {
+	c, err := ParseICalendar(strings.NewReader(testICalendar))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		t        time.Time
+		excluded bool
+	}{
+		{utc(2016, time.December, 23, 23, 59, 59), false},
+		{utc(2016, time.December, 24, 0, 0, 0), true},
+		{utc(2016, time.December, 26, 23, 59, 59), true},
+		{utc(2016, time.December, 27, 0, 0, 0), false},
+		{utc(2017, time.January, 1, 12, 0, 0), true},
+		{utc(2017, time.January, 2, 0, 0, 0), false},
+		{utc(2016, time.December, 28, 1, 59, 59), false},
+		{utc(2016, time.December, 28, 2, 0, 0), true},
+		{utc(2016, time.December, 28, 3, 59, 59), true},
+		{utc(2016, time.December, 28, 4, 0, 0), false},
+		{utc(2016, time.December, 30, 9, 0, 0), false},
+	}
+	for _, test := range tests {
+		if excluded := c.IsExcluded(test.t); excluded != test.excluded {
+			t.Errorf("IsExcluded(%v) = %v WANT %v", test.t, excluded, test.excluded)
+		}
+	}
+	if loc, err := time.LoadLocation("America/New_York"); err == nil {
+		if !c.IsExcluded(time.Date(2016, time.December, 29, 9, 30, 0, 0, loc)) {
+			t.Errorf("IsExcluded(9:30 in %v) = false WANT true", loc)
+		}
+	}
+	next, ok := c.NextIncluded(utc(2016, time.December, 25, 10, 0, 0))
+	if !ok || !next.Equal(utc(2016, time.December, 27, 0, 0, 0)) {
+		t.Errorf("NextIncluded() = %v, %v WANT %v, true", next, ok, utc(2016, time.December, 27, 0, 0, 0))
+	}
+}
+
+func TestParseICalendar_errors(t *testing.T) {
+	tests := []struct {
+		data string
+		err  string
+	}{
+		{"END:VEVENT\n", "sched: icalendar line 1: END:VEVENT without BEGIN:VEVENT"},
+		{"BEGIN:VEVENT\nSUMMARY\nEND:VEVENT\n", `sched: icalendar line 2: property must contain ":"`},
+		{"BEGIN:VEVENT\nSUMMARY:a\nEND:VEVENT\n", "sched: icalendar line 3: VEVENT must have a DTSTART"},
+		{"BEGIN:VEVENT\nDTSTART:2016\nEND:VEVENT\n", `sched: icalendar line 3: DTSTART value "2016" is not a valid date or date-time`},
+		{"BEGIN:VEVENT\nDTSTART;TZID=Nowhere/Else:20160101T000000\nEND:VEVENT\n", "sched: icalendar line 3: DTSTART unknown time zone Nowhere/Else"},
+	}
+	for _, test := range tests {
+		_, err := ParseICalendar(strings.NewReader(test.data))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("ParseICalendar(%q) error = %v WANT %v", test.data, err, test.err)
+		}
+	}
+}
+
+func TestLoadICalendar(t *testing.T) {
+	if _, err := LoadICalendar("does-not-exist.ics"); err == nil {
+		t.Errorf("LoadICalendar() error = nil WANT non-nil")
+	}
+}
@@ -0,0 +1,152 @@
+package sched
+
+import (
+	"fmt"
+	"strings"
+)
+
+//minDaysInMonth is the number of days in the shortest month.
+const minDaysInMonth = 28
+
+//ParseErrors is every ParseError in an expression, in the order that they were found.
+//It is the error that Lint returns.
+type ParseErrors []*ParseError
+
+func (p ParseErrors) Error() string {
+	messages := make([]string, 0, len(p))
+	for _, pe := range p {
+		messages = append(messages, pe.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+//Unwrap returns each ParseError of p so that errors.As and errors.Is can find them.
+func (p ParseErrors) Unwrap() []error {
+	result := make([]error, 0, len(p))
+	for _, pe := range p {
+		result = append(result, pe)
+	}
+	return result
+}
+
+//Pretty returns the Pretty rendering of each ParseError of p.
+func (p ParseErrors) Pretty() string {
+	result := make([]string, 0, len(p))
+	for _, pe := range p {
+		result = append(result, pe.Pretty())
+	}
+	return strings.Join(result, "\n")
+}
+
+//Warning is a valid part of an expression that is likely not what was meant, such as a
+//step that does not evenly divide its field.
+//Field, Part, and Offset are -1 when the warning is not in a field, part, or known position,
+//as for ParseError.
+type Warning struct {
+	Expression  string
+	Description string
+
+	Field     int
+	FieldName string
+	Part      int
+	Offset    int
+	Token     string
+}
+
+func (w *Warning) String() string {
+	return fmt.Sprintf("sched: warning for %q: %v", w.Expression, w.Description)
+}
+
+//Pretty returns the String of w followed by the expression and a line of carets under Token,
+//as for ParseError.Pretty.
+func (w *Warning) Pretty() string {
+	return pretty(w.String(), w.Expression, w.Offset, w.Token)
+}
+
+//Lint checks expression like Parse, but does not stop at the first invalid field or part.
+func Lint(expression string) ([]*Warning, error) {
+	return (&Parser{}).Lint(expression)
+}
+
+//Lint checks expression like p.Parse, but does not stop at the first invalid field or part.
+//The error is nil or ParseErrors with every problem found in expression.
+//The Warnings are about the valid parts of expression that are likely mistakes.
+func (p *Parser) Lint(expression string) ([]*Warning, error) {
+	inner := *p
+	inner.collect = true
+	_, err := inner.parse(expression)
+	if err == nil {
+		return nil, nil
+	}
+	var warnings []*Warning
+	var errs ParseErrors
+	mapExprErrors(err, func(e *exprError) *exprError {
+		pe := e.parseError(expression)
+		if e.warning {
+			warnings = append(warnings, &Warning{
+				Expression:  pe.Expression,
+				Description: pe.Description,
+				Field:       pe.Field,
+				FieldName:   pe.FieldName,
+				Part:        pe.Part,
+				Offset:      pe.Offset,
+				Token:       pe.Token,
+			})
+		} else {
+			errs = append(errs, pe)
+		}
+		return e
+	})
+	if len(errs) == 0 && len(warnings) > 0 {
+		//fields with warnings are not checked against each other when collecting, so the
+		//expression is parsed again to find a schedule that can never fire.
+		if _, err := p.parse(expression); err != nil {
+			mapExprErrors(err, func(e *exprError) *exprError {
+				errs = append(errs, e.parseError(expression))
+				return e
+			})
+		}
+	}
+	if len(errs) == 0 {
+		return warnings, nil
+	}
+	return warnings, errs
+}
+
+//partWarning returns a warning about nexter, which is parsed from part in fi, if pc is
+//collecting and it is likely a mistake.
+//pc may be nil.
+func (pc *parseContext) partWarning(nexter interface{}, part string, fi fieldIndex) error {
+	if !pc.isCollecting() {
+		return nil
+	}
+	switch n := nexter.(type) {
+	case *domFieldNexter:
+		if value, ok := n.fieldNexter.(valueNexter); ok && int(value) > minDaysInMonth {
+			return newExprWarning("day %v does not occur in every month, so the months without it are skipped", int(value)).withToken(part, 0)
+		}
+		nexter = n.fieldNexter
+	case *dowFieldNexter:
+		nexter = n.fieldNexter
+	}
+	rdn, ok := nexter.(*rangeDivNexter)
+	if !ok || rdn.isWrapped() || fi == dom || fi == year {
+		return nil
+	}
+	fr := fi.fieldRange()
+	if rdn.max != fr.max {
+		//the steps end before the field does, so the gap to the start is expected.
+		return nil
+	}
+	last := rdn.ceilStep(rdn.max+1) - rdn.inc
+	if gap := fr.max + 1 - last + rdn.min - fr.min; gap != rdn.inc {
+		return newExprWarning("step value %v leaves a gap of %v from %v to %v", rdn.inc, gap, last, rdn.min).withToken(part, 0)
+	}
+	return nil
+}
+
+func newExprWarning(format string, args ...interface{}) *exprError {
+	result := newExprError(KindSyntax, format, args...)
+	result.warning = true
+	return result
+}
@@ -0,0 +1,104 @@
+package sched
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLint_errors(t *testing.T) {
+	type position struct {
+		field  int
+		part   int
+		offset int
+	}
+	tests := []struct {
+		expression string
+		result     []position
+	}{
+		{"0 0 0 * * *", nil},
+		{"0 70 25 * * 9", []position{{1, 0, 2}, {2, 0, 5}, {5, 0, 12}}},
+		{"a b c", []position{{-1, -1, -1}}},
+		{"0 0 5,a,b * * *; 0 61 * * *", []position{{2, 1, 6}, {2, 2, 8}, {2, 0, 19}}},
+		{"0 0 * * * ~x", []position{{-1, -1, 10}}},
+		{"0 0 31 2 *", []position{{3, 0, 4}}},
+	}
+	for _, test := range tests {
+		_, err := Lint(test.expression)
+		if test.result == nil {
+			if err != nil {
+				t.Errorf("Lint(%q) error = %v WANT <nil>", test.expression, err)
+			}
+			continue
+		}
+		errs, ok := err.(ParseErrors)
+		if !ok || len(errs) != len(test.result) {
+			t.Errorf("Lint(%q) error = %v WANT %v ParseErrors", test.expression, err, len(test.result))
+			continue
+		}
+		for i, pe := range errs {
+			if result := (position{pe.Field, pe.Part, pe.Offset}); result != test.result[i] {
+				t.Errorf("Lint(%q) error %v position = %v WANT %v", test.expression, i, result, test.result[i])
+			}
+		}
+	}
+}
+
+func TestLint_errorsAs(t *testing.T) {
+	_, err := Lint("0 0 25 * * *")
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Field != int(hour) {
+		t.Errorf("errors.As(Lint()) = %v WANT hour field ParseError", pe)
+	}
+	if !errors.Is(err, KindNotInRange) {
+		t.Errorf("errors.Is(Lint(), KindNotInRange) = false WANT true")
+	}
+}
+
+func TestLint_warnings(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     []string
+	}{
+		{"0 */15 * * * *", nil},
+		{"5/15 * * * * *", nil},
+		{"0 0 9-17/7 * * *", nil},
+		{"0 0 0 28 * *", nil},
+		{"0 */7 * * * *", []string{"minute field: step value 7 leaves a gap of 4 from 56 to 0"}},
+		{"0 0 0 * * */2", []string{"day of week field: step value 2 leaves a gap of 1 from 6 to 0"}},
+		{"0 0 0 31 * *", []string{"day of month field: day 31 does not occur in every month, so the months without it are skipped"}},
+		{"0 0 0 1,30 * *; 0 0 */5 * * *", []string{
+			"schedule 1: day of month field: part 2: day 30 does not occur in every month, so the months without it are skipped",
+			"schedule 2: hour field: step value 5 leaves a gap of 4 from 20 to 0",
+		}},
+	}
+	for _, test := range tests {
+		warnings, err := Lint(test.expression)
+		if err != nil {
+			t.Errorf("Lint(%q) error = %v WANT <nil>", test.expression, err)
+			continue
+		}
+		if len(warnings) != len(test.result) {
+			t.Errorf("Lint(%q) warnings = %v WANT %v", test.expression, warnings, test.result)
+			continue
+		}
+		for i, w := range warnings {
+			if w.Description != test.result[i] {
+				t.Errorf("Lint(%q) warning %v = %v WANT %v", test.expression, i, w.Description, test.result[i])
+			}
+		}
+	}
+}
+
+func TestWarning_Pretty(t *testing.T) {
+	warnings, _ := Lint("0 */7 * * * *")
+	want := "sched: warning for \"0 */7 * * * *\": minute field: step value 7 leaves a gap of 4 from 56 to 0\n  0 */7 * * * *\n    ^^^"
+	if len(warnings) != 1 || warnings[0].Pretty() != want {
+		t.Errorf("Lint().Pretty() = %v WANT %q", warnings, want)
+	}
+}
+
+func TestParse_ignoresWarnings(t *testing.T) {
+	if _, err := Parse("0 */7 * * * *"); err != nil {
+		t.Errorf("Parse() error = %v WANT <nil>", err)
+	}
+}
@@ -0,0 +1,33 @@
+package sched
+
+import (
+	"fmt"
+	"time"
+)
+
+//LocationSchedule finds the times of its Schedule in Location, regardless of the location of
+//the times it is given, as for the jobs of a crontab after a CRON_TZ assignment.
+type LocationSchedule struct {
+	Schedule
+	Location *time.Location
+}
+
+func NewLocationSchedule(s Schedule, loc *time.Location) *LocationSchedule {
+	return &LocationSchedule{
+		Schedule: s,
+		Location: loc,
+	}
+}
+
+func (s *LocationSchedule) NextTime(from time.Time) (time.Time, bool) {
+	return s.Schedule.NextTime(from.In(s.Location))
+}
+
+//Expression returns the expression of the Schedule after a TimeZone field for Location.
+func (s *LocationSchedule) Expression() string {
+	return TimeZone + s.Location.String() + " " + s.Schedule.Expression()
+}
+
+func (s *LocationSchedule) String() string {
+	return fmt.Sprintf("sched.LocationSchedule(%v, %v)", s.Schedule, s.Location)
+}
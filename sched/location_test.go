@@ -0,0 +1,74 @@
+package sched
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLocationSchedule_NextTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewLocationSchedule(MustParse("0 0 9 * * *"), newYork)
+	tests := []struct {
+		from   time.Time
+		result time.Time
+	}{
+		{time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC), time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC)},
+		{time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC), time.Date(2026, time.October, 20, 13, 0, 0, 0, time.UTC)},
+		{time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, time.November, 2, 14, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		result, ok := s.NextTime(test.from)
+		if !ok || !result.Equal(test.result) || result.Location() != newYork {
+			t.Errorf("NextTime(%v) = %v, %v WANT %v in %v", test.from, result, ok, test.result, newYork)
+		}
+	}
+	want := "CRON_TZ=America/New_York 0 0 9 * * * *"
+	if result := s.Expression(); result != want {
+		t.Errorf("Expression() = %v WANT %v", result, want)
+	}
+}
+
+func TestParse_timeZone(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     string
+	}{
+		{"CRON_TZ=America/New_York 0 9 * * *", "CRON_TZ=America/New_York 0 0 9 * * * *"},
+		{"CRON_TZ=UTC @hourly ~5m", "CRON_TZ=UTC 0 0 * * * * * ~5m0s"},
+		{"{CRON_TZ=Asia/Tokyo 0 9 * * *; CRON_TZ=UTC 0 9 * * *}", "{CRON_TZ=Asia/Tokyo 0 0 9 * * * *; CRON_TZ=UTC 0 0 9 * * * *}"},
+	}
+	for _, test := range tests {
+		s, err := Parse(test.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v WANT nil", test.expression, err)
+			continue
+		}
+		if result := s.Expression(); result != test.result {
+			t.Errorf("Parse(%q).Expression() = %v WANT %v", test.expression, result, test.result)
+		}
+		if again := MustParse(test.result).Expression(); again != test.result {
+			t.Errorf("Parse(%q).Expression() = %v WANT %v", test.result, again, test.result)
+		}
+	}
+
+	s := MustParse("CRON_TZ=America/New_York 0 9 * * *")
+	from := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	if next, _ := s.NextTime(from); !next.Equal(time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextTime(%v) = %v WANT 13:00 UTC", from, next)
+	}
+
+	for _, expression := range []string{"CRON_TZ=Nowhere/Nothing 0 9 * * *", "CRON_TZ= 0 9 * * *"} {
+		_, err := Parse(expression)
+		if pe := (*ParseError)(nil); !errors.As(err, &pe) || pe.Kind != KindTimeZone || pe.Offset != 0 {
+			t.Errorf("Parse(%q) error = %v WANT a time zone error at 0", expression, err)
+		}
+	}
+	_, err := Parse("CRON_TZ=UTC 0 70 * * *")
+	if pe := (*ParseError)(nil); !errors.As(err, &pe) || pe.Token != "70" || pe.Offset != 14 {
+		t.Errorf("Parse() error = %#v WANT 70 at 14", err)
+	}
+}
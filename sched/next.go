@@ -2,11 +2,25 @@ package sched
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
+//fieldNexter returns the smallest value in its set that is strictly greater than now
+//and false, or its smallest value and true if it has to wrap around.
 type fieldNexter interface {
 	next(int) (int, bool)
+	String() string
+}
+
+//ceil returns the smallest value of fn that is greater than or equal to now.
+func ceil(fn fieldNexter, now int) (int, bool) {
+	return fn.next(now - 1)
+}
+
+func contains(fn fieldNexter, value int) bool {
+	result, wrapped := ceil(fn, value)
+	return !wrapped && result == value
 }
 
 type valueNexter int
@@ -16,7 +30,11 @@ func newValueNexter(value int) valueNexter {
 }
 
 func (vn valueNexter) next(now int) (int, bool) {
-	return now, true
+	return int(vn), now >= int(vn)
+}
+
+func (vn valueNexter) String() string {
+	return fmt.Sprint(int(vn))
 }
 
 type anyNexter struct {
@@ -29,9 +47,13 @@ func newAnyNexter(min, max int) *anyNexter {
 	}
 }
 
+//rangeDivNexter is every inc'th value of its range starting at min.
+//If the range wraps around then field is the range of the field, and the steps continue
+//from the end of the field to the start.
 type rangeDivNexter struct {
 	*rangeNexter
-	inc int
+	inc   int
+	field *fieldRange
 }
 
 func newRangeDivNexter(rn *rangeNexter, inc int) *rangeDivNexter {
@@ -41,16 +63,67 @@ func newRangeDivNexter(rn *rangeNexter, inc int) *rangeDivNexter {
 	}
 }
 
+func newWrapRangeDivNexter(rn *rangeNexter, inc int, field *fieldRange) *rangeDivNexter {
+	rdn := newRangeDivNexter(rn, inc)
+	rdn.field = field
+	return rdn
+}
+
 func (rdn *rangeDivNexter) next(now int) (int, bool) {
-	if now < rdn.min {
-		return rdn.min, false
-	}
-	value := now - rdn.min
-	result := rdn.min + value + (rdn.inc - (value % rdn.inc))
-	if result > rdn.max {
+	if !rdn.isWrapped() {
+		if now < rdn.min {
+			return rdn.min, false
+		}
+		if result := rdn.ceilStep(now + 1); result <= rdn.max {
+			return result, false
+		}
 		return rdn.min, true
 	}
-	return result, false
+
+	//values after the wrap are shifted up by span so that they continue the steps from min.
+	span := rdn.field.max - rdn.field.min + 1
+	if now < rdn.max {
+		if result := rdn.ceilStep(maxInt(now+1, rdn.field.min) + span); result <= rdn.max+span {
+			return result - span, false
+		}
+	}
+	if result := rdn.ceilStep(maxInt(now+1, rdn.min)); result <= rdn.field.max {
+		return result, false
+	}
+	if result := rdn.ceilStep(rdn.field.min + span); result <= rdn.max+span {
+		return result - span, true
+	}
+	return rdn.min, true
+}
+
+//reach returns how far past min the values of rdn may be.
+func (rdn *rangeDivNexter) reach() int {
+	if rdn.isWrapped() {
+		return rdn.max + rdn.field.max - rdn.field.min + 1 - rdn.min
+	}
+	return rdn.max - rdn.min
+}
+
+//ceilStep returns the smallest step from min that is greater than or equal to value.
+//value must not be less than min.
+func (rdn *rangeDivNexter) ceilStep(value int) int {
+	steps := (value - rdn.min + rdn.inc - 1) / rdn.inc
+	return rdn.min + steps*rdn.inc
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (rdn *rangeDivNexter) String() string {
+	if rdn.min == rdn.max {
+		//a step that starts at the end of its field, such as 59/30, is a single starting value.
+		return fmt.Sprintf("%v%v%v", rdn.min, Slash, rdn.inc)
+	}
+	return fmt.Sprintf("%v%v%v", rdn.rangeNexter, Slash, rdn.inc)
 }
 
 type rangeNexter struct {
@@ -76,6 +149,45 @@ func (rn *rangeNexter) next(now int) (int, bool) {
 	return result, false
 }
 
+func (rn *rangeNexter) isWrapped() bool {
+	return rn.min > rn.max
+}
+
+func (rn *rangeNexter) String() string {
+	return fmt.Sprintf("%v%v%v", rn.min, Hyphen, rn.max)
+}
+
+//wrapRangeNexter is a range that wraps around from the end of its field to the start,
+//such as 22-2 for hours. min is greater than max.
+type wrapRangeNexter struct {
+	*rangeNexter
+	field *fieldRange
+}
+
+func newWrapRangeNexter(rn *rangeNexter, field *fieldRange) *wrapRangeNexter {
+	return &wrapRangeNexter{
+		rangeNexter: rn,
+		field:       field,
+	}
+}
+
+func (wrn *wrapRangeNexter) next(now int) (int, bool) {
+	result := now + 1
+	if result < wrn.field.min {
+		return wrn.field.min, false
+	}
+	if result <= wrn.max {
+		return result, false
+	}
+	if result < wrn.min {
+		return wrn.min, false
+	}
+	if result <= wrn.field.max {
+		return result, false
+	}
+	return wrn.field.min, true
+}
+
 type multiNexter []fieldNexter
 
 func newMultiNexter(fns ...fieldNexter) multiNexter {
@@ -83,75 +195,233 @@ func newMultiNexter(fns ...fieldNexter) multiNexter {
 }
 
 func (mn multiNexter) next(now int) (int, bool) {
-	return now, true
+	result, wrapped := invalidValue, true
+	for _, fn := range mn {
+		value, w := fn.next(now)
+		if result == invalidValue || (wrapped && !w) || (wrapped == w && value < result) {
+			result, wrapped = value, w
+		}
+	}
+	return result, wrapped
+}
+
+func (mn multiNexter) String() string {
+	parts := make([]string, 0, len(mn))
+	for _, fn := range mn {
+		parts = append(parts, fn.String())
+	}
+	return strings.Join(parts, Comma)
 }
 
+//dateFieldNexter returns the smallest day of the month of t that is strictly greater
+//than now and false, or invalidValue and true if there is no such day.
 type dateFieldNexter interface {
-	next(now int, time time.Time) (int, bool)
+	next(now int, t time.Time) (int, bool)
+	String() string
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 12, 0, 0, 0, t.Location()).Day()
+}
+
+func weekdayOf(day int, t time.Time) int {
+	first := time.Date(t.Year(), t.Month(), 1, 12, 0, 0, 0, t.Location()).Weekday()
+	return (int(first) + day - 1) % 7
 }
 
 type multiDateFieldNexter []dateFieldNexter
 
-func (mdn multiDateFieldNexter) next(now int, time time.Time) (int, bool) {
-	return now, true
+func (mdn multiDateFieldNexter) next(now int, t time.Time) (int, bool) {
+	result := invalidValue
+	for _, dfn := range mdn {
+		day, wrapped := dfn.next(now, t)
+		if !wrapped && (result == invalidValue || day < result) {
+			result = day
+		}
+	}
+	return result, result == invalidValue
+}
+
+func (mdn multiDateFieldNexter) String() string {
+	parts := make([]string, 0, len(mdn))
+	for _, dfn := range mdn {
+		parts = append(parts, dfn.String())
+	}
+	return strings.Join(parts, Comma)
 }
 
 type domFieldNexter struct {
 	fieldNexter
 	isLast    bool
 	isWeekday bool
+
+	//offset is the number of days before the last day of the month when isLast.
+	offset int
 }
 
-func newDomFieldNexter(nexter fieldNexter, isLast, isWeekday bool) (*domFieldNexter, error) {
+func newDomFieldNexter(nexter fieldNexter, isLast, isWeekday bool, offset int) (*domFieldNexter, error) {
+	if offset != 0 && !isLast {
+		return nil, newExprError(KindModifier, "offset requires the %q modifier", Last)
+	}
+	if offset < 0 || offset > MaxDom-MinDom {
+		return nil, newExprError(KindModifier, "invalid offset for %q modifier", Last)
+	}
 	if isLast {
 		switch fn := nexter.(type) {
 		case *rangeNexter:
 			if fn.min != MinDom || fn.max != MaxDom {
-				return nil, fmt.Errorf("invalid range for %q modifier", Last)
+				return nil, newExprError(KindModifier, "invalid range for %q modifier", Last)
 			}
 		case *rangeDivNexter:
 			if fn.min != MinDom || fn.max != MaxDom || fn.inc != 1 {
-				return nil, fmt.Errorf("invalid range or step for %q modifier", Last)
+				return nil, newExprError(KindModifier, "invalid range or step for %q modifier", Last)
 			}
+		case *wrapRangeNexter:
+			return nil, newExprError(KindModifier, "invalid range for %q modifier", Last)
 		case valueNexter:
-			return nil, fmt.Errorf("cannot have single, static value for %q modifier", Last)
+			return nil, newExprError(KindModifier, "cannot have single, static value for %q modifier", Last)
 		}
 	}
 	return &domFieldNexter{
 		fieldNexter: nexter,
 		isLast:      isLast,
 		isWeekday:   isWeekday,
+		offset:      offset,
 	}, nil
 }
 
-func (dfn *domFieldNexter) next(now int, time time.Time) (int, bool) {
-	return now, true
+func (dfn *domFieldNexter) next(now int, t time.Time) (int, bool) {
+	days := daysInMonth(t)
+	day := invalidValue
+	if dfn.isLast {
+		day = days - dfn.offset
+	} else if dfn.isWeekday {
+		day = int(dfn.fieldNexter.(valueNexter))
+	} else {
+		result, wrapped := dfn.fieldNexter.next(now)
+		if wrapped {
+			return invalidValue, true
+		}
+		day = result
+	}
+	if day < MinDom || day > days {
+		return invalidValue, true
+	}
+	if dfn.isWeekday {
+		day = nearestWeekday(day, t)
+	}
+	if day <= now {
+		return invalidValue, true
+	}
+	return day, false
+}
+
+//nearestWeekday returns the weekday closest to day without leaving the month of t.
+func nearestWeekday(day int, t time.Time) int {
+	switch time.Weekday(weekdayOf(day, t)) {
+	case time.Saturday:
+		if day == MinDom {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == daysInMonth(t) {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+func (dfn *domFieldNexter) String() string {
+	result := ""
+	if dfn.fieldNexter != nil {
+		result = dfn.fieldNexter.String()
+	}
+	if dfn.isLast {
+		result += Last
+	}
+	if dfn.offset != 0 {
+		result += fmt.Sprintf("%v%v", Hyphen, dfn.offset)
+	}
+	if dfn.isWeekday {
+		result += Weekday
+	}
+	return result
 }
 
 type dowFieldNexter struct {
 	fieldNexter
 	isLast bool
 	number int
+
+	//fromEnd is whether number counts occurrences back from the end of the month.
+	fromEnd bool
 }
 
+//number may be negative to count occurrences back from the end of the month.
 func newDowFieldNexter(nexter fieldNexter, isLast bool, number int, isNumber bool) (*dowFieldNexter, error) {
+	fromEnd := number < 0
+	if fromEnd {
+		number = -number
+	}
 	isNumberValid := number >= MinHash && number <= MaxHash
 	if !isNumberValid && isNumber {
-		return nil, fmt.Errorf("invalid value for %q modifier", Hash)
+		return nil, newExprError(KindModifier, "invalid value for %q modifier", Hash)
 	}
 	if isLast && isNumber {
-		return nil, fmt.Errorf("cannot have %q and %q modifiers together", Last, Hash)
+		return nil, newExprError(KindModifier, "cannot have %q and %q modifiers together", Last, Hash)
 	}
 	if !isNumber {
 		number = invalidValue
+		fromEnd = false
 	}
 	return &dowFieldNexter{
 		fieldNexter: nexter,
 		isLast:      isLast,
 		number:      number,
+		fromEnd:     fromEnd,
 	}, nil
 }
 
-func (dfn *dowFieldNexter) next(now int, time time.Time) (int, bool) {
-	return now, true
+func (dfn *dowFieldNexter) next(now int, t time.Time) (int, bool) {
+	days := daysInMonth(t)
+	for day := now + 1; day <= days; day++ {
+		if contains(dfn.fieldNexter, weekdayOf(day, t)) && dfn.isOccurrence(day, days) {
+			return day, false
+		}
+	}
+	return invalidValue, true
+}
+
+//isOccurrence returns whether day is the occurrence of its weekday selected by the
+//modifiers of dfn in a month with days days.
+func (dfn *dowFieldNexter) isOccurrence(day, days int) bool {
+	fromStart := (day-MinDom)/7 + 1
+	fromEnd := (days-day)/7 + 1
+	if dfn.isLast {
+		return fromEnd == 1
+	}
+	if dfn.number == invalidValue {
+		return true
+	}
+	if dfn.fromEnd {
+		return fromEnd == dfn.number
+	}
+	return fromStart == dfn.number
+}
+
+func (dfn *dowFieldNexter) String() string {
+	result := dfn.fieldNexter.String()
+	if dfn.isLast {
+		result += Last
+	}
+	if dfn.number != invalidValue {
+		result += Hash
+		if dfn.fromEnd {
+			result += Hyphen
+		}
+		result += fmt.Sprint(dfn.number)
+	}
+	return result
 }
@@ -0,0 +1,283 @@
+package sched
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValueNexter_next(t *testing.T) {
+	tests := []struct {
+		value   int
+		now     int
+		result  int
+		wrapped bool
+	}{
+		{5, 0, 5, false},
+		{5, 4, 5, false},
+		{5, 5, 5, true},
+		{5, 6, 5, true},
+	}
+	for _, test := range tests {
+		result, wrapped := valueNexter(test.value).next(test.now)
+		if result != test.result || wrapped != test.wrapped {
+			t.Errorf("valueNexter(%v).next(%v) = %v, %v WANT %v, %v", test.value, test.now, result, wrapped, test.result, test.wrapped)
+		}
+	}
+}
+
+func TestRangeDivNexter_next(t *testing.T) {
+	rdn := newRangeDivNexter(newRangeNexter(10, 40), 15)
+	tests := []struct {
+		now     int
+		result  int
+		wrapped bool
+	}{
+		{0, 10, false},
+		{9, 10, false},
+		{10, 25, false},
+		{11, 25, false},
+		{24, 25, false},
+		{25, 40, false},
+		{40, 10, true},
+		{59, 10, true},
+	}
+	for _, test := range tests {
+		result, wrapped := rdn.next(test.now)
+		if result != test.result || wrapped != test.wrapped {
+			t.Errorf("%v.next(%v) = %v, %v WANT %v, %v", rdn, test.now, result, wrapped, test.result, test.wrapped)
+		}
+	}
+}
+
+func TestWrapRangeNexter_next(t *testing.T) {
+	wrn := newWrapRangeNexter(newRangeNexter(22, 2), hour.fieldRange())
+	tests := []struct {
+		now     int
+		result  int
+		wrapped bool
+	}{
+		{-1, 0, false},
+		{0, 1, false},
+		{1, 2, false},
+		{2, 22, false},
+		{12, 22, false},
+		{21, 22, false},
+		{22, 23, false},
+		{23, 0, true},
+	}
+	for _, test := range tests {
+		result, wrapped := wrn.next(test.now)
+		if result != test.result || wrapped != test.wrapped {
+			t.Errorf("%v.next(%v) = %v, %v WANT %v, %v", wrn, test.now, result, wrapped, test.result, test.wrapped)
+		}
+	}
+}
+
+func TestRangeDivNexter_next_wrapped(t *testing.T) {
+	tests := []struct {
+		rdn     *rangeDivNexter
+		now     int
+		result  int
+		wrapped bool
+	}{
+		{newWrapRangeDivNexter(newRangeNexter(22, 2), 2, hour.fieldRange()), -1, 0, false},
+		{newWrapRangeDivNexter(newRangeNexter(22, 2), 2, hour.fieldRange()), 0, 2, false},
+		{newWrapRangeDivNexter(newRangeNexter(22, 2), 2, hour.fieldRange()), 2, 22, false},
+		{newWrapRangeDivNexter(newRangeNexter(22, 2), 2, hour.fieldRange()), 22, 0, true},
+		{newWrapRangeDivNexter(newRangeNexter(22, 2), 2, hour.fieldRange()), 23, 0, true},
+		{newWrapRangeDivNexter(newRangeNexter(21, 3), 2, hour.fieldRange()), -1, 1, false},
+		{newWrapRangeDivNexter(newRangeNexter(21, 3), 2, hour.fieldRange()), 1, 3, false},
+		{newWrapRangeDivNexter(newRangeNexter(21, 3), 2, hour.fieldRange()), 3, 21, false},
+		{newWrapRangeDivNexter(newRangeNexter(21, 3), 2, hour.fieldRange()), 21, 23, false},
+		{newWrapRangeDivNexter(newRangeNexter(21, 3), 2, hour.fieldRange()), 23, 1, true},
+		{newWrapRangeDivNexter(newRangeNexter(50, 5), 20, minute.fieldRange()), 0, 50, false},
+		{newWrapRangeDivNexter(newRangeNexter(50, 5), 20, minute.fieldRange()), 50, 50, true},
+		{newWrapRangeDivNexter(newRangeNexter(11, 2), 2, month.fieldRange()), 0, 1, false},
+		{newWrapRangeDivNexter(newRangeNexter(11, 2), 2, month.fieldRange()), 1, 11, false},
+		{newWrapRangeDivNexter(newRangeNexter(11, 2), 2, month.fieldRange()), 11, 1, true},
+	}
+	for _, test := range tests {
+		result, wrapped := test.rdn.next(test.now)
+		if result != test.result || wrapped != test.wrapped {
+			t.Errorf("%v.next(%v) = %v, %v WANT %v, %v", test.rdn, test.now, result, wrapped, test.result, test.wrapped)
+		}
+	}
+}
+
+func TestMultiNexter_next(t *testing.T) {
+	mn := newMultiNexter(valueNexter(30), newRangeNexter(5, 10), valueNexter(2))
+	tests := []struct {
+		now     int
+		result  int
+		wrapped bool
+	}{
+		{0, 2, false},
+		{2, 5, false},
+		{7, 8, false},
+		{10, 30, false},
+		{30, 2, true},
+	}
+	for _, test := range tests {
+		result, wrapped := mn.next(test.now)
+		if result != test.result || wrapped != test.wrapped {
+			t.Errorf("%v.next(%v) = %v, %v WANT %v, %v", mn, test.now, result, wrapped, test.result, test.wrapped)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	fn := newMultiNexter(valueNexter(0), newRangeDivNexter(newRangeNexter(10, 20), 5))
+	tests := []struct {
+		value  int
+		result bool
+	}{
+		{0, true},
+		{1, false},
+		{10, true},
+		{12, false},
+		{15, true},
+		{20, true},
+		{25, false},
+	}
+	for _, test := range tests {
+		if result := contains(fn, test.value); result != test.result {
+			t.Errorf("contains(%v, %v) = %v WANT %v", fn, test.value, result, test.result)
+		}
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		t      time.Time
+		result int
+	}{
+		{utc(2016, time.January, 20, 0, 0, 0), 31},
+		{utc(2016, time.February, 1, 0, 0, 0), 29},
+		{utc(2017, time.February, 28, 0, 0, 0), 28},
+		{utc(2017, time.April, 30, 23, 59, 59), 30},
+	}
+	for _, test := range tests {
+		if result := daysInMonth(test.t); result != test.result {
+			t.Errorf("daysInMonth(%v) = %v WANT %v", test.t, result, test.result)
+		}
+	}
+}
+
+func TestWeekdayOf(t *testing.T) {
+	march := utc(2016, time.March, 20, 0, 0, 0)
+	for day := 1; day <= 31; day++ {
+		want := int(utc(2016, time.March, day, 0, 0, 0).Weekday())
+		if result := weekdayOf(day, march); result != want {
+			t.Errorf("weekdayOf(%v, %v) = %v WANT %v", day, march, result, want)
+		}
+	}
+}
+
+func TestMultiDateFieldNexter_next(t *testing.T) {
+	mdn := multiDateFieldNexter{
+		&domFieldNexter{fieldNexter: valueNexter(20)},
+		&dowFieldNexter{fieldNexter: valueNexter(int(time.Monday)), number: invalidValue},
+	}
+	march := utc(2016, time.March, 1, 0, 0, 0)
+	tests := []struct {
+		now     int
+		result  int
+		wrapped bool
+	}{
+		{0, 7, false},
+		{7, 14, false},
+		{14, 20, false},
+		{20, 21, false},
+		{28, invalidValue, true},
+	}
+	for _, test := range tests {
+		result, wrapped := mdn.next(test.now, march)
+		if result != test.result || wrapped != test.wrapped {
+			t.Errorf("%v.next(%v) = %v, %v WANT %v, %v", mdn, test.now, result, wrapped, test.result, test.wrapped)
+		}
+	}
+}
+
+func TestDomFieldNexter_next(t *testing.T) {
+	tests := []struct {
+		dfn     *domFieldNexter
+		now     int
+		t       time.Time
+		result  int
+		wrapped bool
+	}{
+		{&domFieldNexter{fieldNexter: newRangeNexter(10, 31)}, 0, utc(2016, time.April, 1, 0, 0, 0), 10, false},
+		{&domFieldNexter{fieldNexter: newRangeNexter(10, 31)}, 30, utc(2016, time.April, 1, 0, 0, 0), invalidValue, true},
+		{&domFieldNexter{fieldNexter: newRangeNexter(10, 31)}, 30, utc(2016, time.May, 1, 0, 0, 0), 31, false},
+		{&domFieldNexter{isLast: true}, 0, utc(2016, time.February, 1, 0, 0, 0), 29, false},
+		{&domFieldNexter{isLast: true}, 29, utc(2016, time.February, 1, 0, 0, 0), invalidValue, true},
+		{&domFieldNexter{isLast: true, offset: 3}, 0, utc(2016, time.February, 1, 0, 0, 0), 26, false},
+		{&domFieldNexter{isLast: true, offset: 3}, 0, utc(2016, time.March, 1, 0, 0, 0), 28, false},
+		{&domFieldNexter{isLast: true, offset: 30}, 0, utc(2016, time.February, 1, 0, 0, 0), invalidValue, true},
+		{&domFieldNexter{isLast: true, isWeekday: true}, 0, utc(2016, time.July, 1, 0, 0, 0), 29, false},
+		{&domFieldNexter{isLast: true, isWeekday: true}, 0, utc(2016, time.April, 1, 0, 0, 0), 29, false},
+		{&domFieldNexter{isLast: true, isWeekday: true}, 0, utc(2016, time.March, 1, 0, 0, 0), 31, false},
+		{&domFieldNexter{fieldNexter: valueNexter(15), isWeekday: true}, 0, utc(2016, time.January, 1, 0, 0, 0), 15, false},
+		{&domFieldNexter{fieldNexter: valueNexter(15), isWeekday: true}, 0, utc(2016, time.May, 1, 0, 0, 0), 16, false},
+		{&domFieldNexter{fieldNexter: valueNexter(15), isWeekday: true}, 0, utc(2016, time.October, 1, 0, 0, 0), 14, false},
+		{&domFieldNexter{fieldNexter: valueNexter(15), isWeekday: true}, 14, utc(2016, time.October, 1, 0, 0, 0), invalidValue, true},
+		{&domFieldNexter{fieldNexter: valueNexter(1), isWeekday: true}, 0, utc(2016, time.October, 1, 0, 0, 0), 3, false},
+		{&domFieldNexter{fieldNexter: valueNexter(1), isWeekday: true}, 0, utc(2016, time.May, 1, 0, 0, 0), 2, false},
+		{&domFieldNexter{fieldNexter: valueNexter(31), isWeekday: true}, 0, utc(2016, time.July, 1, 0, 0, 0), 29, false},
+		{&domFieldNexter{fieldNexter: valueNexter(30), isWeekday: true}, 0, utc(2017, time.April, 1, 0, 0, 0), 28, false},
+		{&domFieldNexter{fieldNexter: valueNexter(31), isWeekday: true}, 0, utc(2017, time.April, 1, 0, 0, 0), invalidValue, true},
+	}
+	for _, test := range tests {
+		result, wrapped := test.dfn.next(test.now, test.t)
+		if result != test.result || wrapped != test.wrapped {
+			t.Errorf("%v.next(%v, %v) = %v, %v WANT %v, %v", test.dfn, test.now, test.t, result, wrapped, test.result, test.wrapped)
+		}
+	}
+}
+
+func TestNearestWeekday(t *testing.T) {
+	tests := []struct {
+		day    int
+		t      time.Time
+		result int
+	}{
+		{15, utc(2016, time.January, 1, 0, 0, 0), 15},
+		{15, utc(2016, time.May, 1, 0, 0, 0), 16},
+		{15, utc(2016, time.October, 1, 0, 0, 0), 14},
+		{1, utc(2016, time.October, 1, 0, 0, 0), 3},
+		{1, utc(2016, time.May, 1, 0, 0, 0), 2},
+		{30, utc(2016, time.April, 1, 0, 0, 0), 29},
+		{30, utc(2017, time.April, 1, 0, 0, 0), 28},
+		{30, utc(2017, time.September, 1, 0, 0, 0), 29},
+	}
+	for _, test := range tests {
+		if result := nearestWeekday(test.day, test.t); result != test.result {
+			t.Errorf("nearestWeekday(%v, %v) = %v WANT %v", test.day, test.t, result, test.result)
+		}
+	}
+}
+
+func TestDowFieldNexter_isOccurrence(t *testing.T) {
+	tests := []struct {
+		dfn    *dowFieldNexter
+		day    int
+		days   int
+		result bool
+	}{
+		{&dowFieldNexter{number: invalidValue}, 1, 31, true},
+		{&dowFieldNexter{number: 1}, 7, 31, true},
+		{&dowFieldNexter{number: 1}, 8, 31, false},
+		{&dowFieldNexter{number: 5}, 29, 31, true},
+		{&dowFieldNexter{number: 5}, 28, 31, false},
+		{&dowFieldNexter{number: 1, fromEnd: true}, 25, 31, true},
+		{&dowFieldNexter{number: 1, fromEnd: true}, 24, 31, false},
+		{&dowFieldNexter{number: 2, fromEnd: true}, 22, 29, true},
+		{&dowFieldNexter{isLast: true, number: invalidValue}, 23, 29, true},
+		{&dowFieldNexter{isLast: true, number: invalidValue}, 22, 29, false},
+	}
+	for _, test := range tests {
+		if result := test.dfn.isOccurrence(test.day, test.days); result != test.result {
+			t.Errorf("%v.isOccurrence(%v, %v) = %v WANT %v", test.dfn, test.day, test.days, result, test.result)
+		}
+	}
+}
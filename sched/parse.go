@@ -2,45 +2,313 @@ package sched
 
 import (
 	"fmt"
+	"math/rand"
 	"strconv"
 	"strings"
 	"time"
 )
 
-var errEmpty error = fmt.Errorf("cannot be empty")
-var errNotInRange error = fmt.Errorf("not in range")
-var errNoHyphen error = fmt.Errorf("does not contain hyphen")
-var errParseIntegerAlias = fmt.Errorf("must be a decimal integer or valid string alias")
-var errParseInteger = fmt.Errorf("must be a decimal integer")
+var errEmpty = newExprError(KindEmpty, "cannot be empty")
+var errNotInRange = newExprError(KindNotInRange, "not in range")
+var errNoHyphen = newExprError(KindSyntax, "does not contain hyphen")
+var errParseIntegerAlias = newExprError(KindNotInteger, "must be a decimal integer or valid string alias")
+var errParseInteger = newExprError(KindNotInteger, "must be a decimal integer")
 
 const (
-	Asterisk = "*"
-	Question = "?"
-	Hyphen   = "-"
-	Slash    = "/"
-	Comma    = ","
-	Hash     = "#"
-	Last     = "L"
-	Weekday  = "W"
+	Asterisk   = "*"
+	Question   = "?"
+	Hyphen     = "-"
+	Slash      = "/"
+	Comma      = ","
+	Hash       = "#"
+	Last       = "L"
+	Weekday    = "W"
+	Tilde      = "~"
+	Hashed     = "H"
+	Random     = "R"
+	OpenParen  = "("
+	CloseParen = ")"
+
+	OpenBracket = "["
+	Runs        = "x"
+
+	Semicolon     = ";"
+	OpenBrace     = "{"
+	CloseBrace    = "}"
+	KeywordAnd    = "AND"
+	KeywordExcept = "EXCEPT"
+
+	//TimeZone starts a first field that names the location of the rest of an expression,
+	//as in "CRON_TZ=America/New_York 0 9 * * *".
+	TimeZone = "CRON_TZ="
 
 	FieldSeparators = " \t"
 	TrimCutset      = FieldSeparators + "\n"
 )
 
-type ParseError struct {
-	Expression  string
-	Description string
+//Parser parses expressions into Schedules.
+//The zero value is ready to use and behaves like Parse.
+type Parser struct {
+	//Seed is used to resolve Hashed field values and to offset splay schedules.
+	Seed string
+
+	//Source provides the values chosen for Random field values.
+	//If nil, a Source seeded with the current time is used.
+	//A Source is not safe for concurrent use, so neither is a Parser with one set.
+	Source rand.Source
+
+	//Dialect is the grammar of the expressions to parse.
+	//If nil, Standard is used.
+	Dialect *Dialect
+
+	//Lenient is whether month and day of week names may be any prefix of at least three
+	//characters of their full names, such as "Janu" or "Thurs". Otherwise only the three
+	//character abbreviations and full names are accepted.
+	Lenient bool
+
+	//Directives maps custom directives, such as "@business-open", to the expressions
+	//they stand for. Use AddDirective to add to it.
+	Directives map[string]string
+
+	//Now returns the time that runs are counted from in expressions with a maximum number
+	//of runs but no start time.
+	//If nil, time.Now is used.
+	Now func() time.Time
+
+	//collect is whether every error and warning in an expression is returned as an
+	//exprErrors, as for Lint, rather than only the first error.
+	collect bool
+}
+
+func (p *Parser) now() time.Time {
+	if p.Now == nil {
+		return time.Now()
+	}
+	return p.Now()
+}
+
+//AddDirective makes directive stand for expression in every expression p parses.
+//directive must start with "@", must not be a built in directive, and is case insensitive.
+//expression must be parseable by p.
+func (p *Parser) AddDirective(directive, expression string) error {
+	if !strings.HasPrefix(directive, "@") || len(Fields(directive)) != 1 || Fields(directive)[0] != directive {
+		return fmt.Errorf("sched: directive %q must be a single field starting with %q", directive, "@")
+	}
+	if isBuiltInDirective(directive) {
+		return fmt.Errorf("sched: directive %q is built in", directive)
+	}
+	if _, err := p.Parse(expression); err != nil {
+		return err
+	}
+	if p.Directives == nil {
+		p.Directives = map[string]string{}
+	}
+	p.Directives[strings.ToLower(directive)] = expression
+	return nil
+}
+
+//expandDirective returns the expression that expression stands for if it is a single
+//custom directive, or expression otherwise.
+func (p *Parser) expandDirective(expression string) string {
+	fields := Fields(expression)
+	if len(fields) != 1 {
+		return expression
+	}
+	if result, ok := p.Directives[strings.ToLower(fields[0])]; ok {
+		return result
+	}
+	return expression
+}
+
+func (p *Parser) Parse(expression string) (Schedule, error) {
+	//ParseErrors should be returned from this function and no others.
+	result, err := p.parse(expression)
+	if err != nil {
+		return nil, asExprError(err).parseError(expression)
+	}
+	return result, nil
+}
+
+//parse parses expression, which may be a composite of other expressions.
+func (p *Parser) parse(expression string) (Schedule, error) {
+	parts, separator, err := splitComposite(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) > 1 {
+		return p.parseComposite(expression, parts, separator)
+	}
+	rest, loc, offset, err := splitTimeZoneField(expression)
+	if err != nil {
+		return nil, err
+	}
+	if loc != nil {
+		result, err := p.parse(rest)
+		if err != nil {
+			return nil, mapExprErrors(err, func(e *exprError) *exprError {
+				return e.shift(offset)
+			})
+		}
+		return NewLocationSchedule(result, loc), nil
+	}
+	rest, window, hasSplay, err := splitSplayField(expression)
+	if err != nil {
+		return nil, err
+	}
+	rest, bounds, err := splitBoundsFields(rest)
+	if err != nil {
+		return nil, err
+	}
+	var result Schedule
+	restOffset := len(rest) - len(strings.TrimLeft(rest, TrimCutset))
+	rest = strings.Trim(rest, TrimCutset)
+	if expanded := p.expandDirective(rest); expanded != rest {
+		//custom directives are not expanded within each other, so they cannot recurse.
+		inner := *p
+		inner.Directives = nil
+		result, err = inner.parse(expanded)
+		if err != nil {
+			//positions in the expanded expression are reported at the directive.
+			return nil, mapExprErrors(err, func(e *exprError) *exprError {
+				return e.withToken(rest, restOffset)
+			})
+		}
+	} else if isBraced(rest) {
+		result, err = p.parse(rest[len(OpenBrace) : len(rest)-len(CloseBrace)])
+		restOffset += len(OpenBrace)
+	} else {
+		result, err = parseExpression(rest, p.newParseContext())
+	}
+	if err != nil {
+		return nil, mapExprErrors(err, func(e *exprError) *exprError {
+			return e.locate(rest).shift(restOffset)
+		})
+	}
+	if bounds != nil {
+		if bounds.MaxRuns > 0 && bounds.Start.IsZero() {
+			bounds.Start = p.now().Truncate(time.Second)
+		}
+		bounds.Schedule = result
+		result = bounds
+	}
+	if hasSplay {
+		return NewSplaySchedule(result, p.Seed, window), nil
+	}
+	return result, nil
+}
+
+//parseComposite parses each of parts, which are split from expression in order, and
+//combines them according to separator.
+//Except schedules are combined from left to right.
+func (p *Parser) parseComposite(expression string, parts []string, separator string) (Schedule, error) {
+	schedules := make([]Schedule, 0, len(parts))
+	var errs exprErrors
+	offset := 0
+	for i, part := range parts {
+		offset += strings.Index(expression[offset:], part)
+		s, err := p.parse(part)
+		if err != nil {
+			partOffset := offset
+			err = mapExprErrors(err, func(e *exprError) *exprError {
+				return wrapExprError(e.locate(part).shift(partOffset), "schedule %v: %v", i+1, e.desc)
+			})
+			if !p.collect {
+				return nil, err
+			}
+			errs = errs.add(err)
+		}
+		schedules = append(schedules, s)
+		offset += len(part)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	switch separator {
+	case Semicolon:
+		return Union(schedules...), nil
+	case KeywordAnd:
+		return Intersect(schedules...), nil
+	}
+	result := schedules[0]
+	for _, exclude := range schedules[1:] {
+		result = Except(result, exclude)
+	}
+	return result, nil
 }
 
-func newParseError(exp, desc string) *ParseError {
-	return &ParseError{
-		Expression:  exp,
-		Description: desc,
+func (p *Parser) newParseContext() *parseContext {
+	source := p.Source
+	if source == nil {
+		source = rand.NewSource(time.Now().UnixNano())
 	}
+	return &parseContext{
+		seed:    p.Seed,
+		rand:    rand.New(source),
+		dialect: p.Dialect,
+		lenient: p.Lenient,
+		collect: p.collect,
+	}
+}
+
+//parseContext holds the state that is shared by all fields of a single expression.
+type parseContext struct {
+	seed    string
+	rand    *rand.Rand
+	dialect *Dialect
+	lenient bool
+	collect bool
 }
 
-func (p *ParseError) Error() string {
-	return fmt.Sprintf("sched: could not parse %q: %v", p.Expression, p.Description)
+//getDialect returns the Dialect of pc or Standard if it does not have one.
+//pc may be nil.
+func (pc *parseContext) getDialect() *Dialect {
+	if pc == nil || pc.dialect == nil {
+		return &Standard
+	}
+	return pc.dialect
+}
+
+//withDialect returns a copy of pc that parses in d.
+//pc may be nil.
+func (pc *parseContext) withDialect(d *Dialect) *parseContext {
+	result := &parseContext{}
+	if pc != nil {
+		*result = *pc
+	}
+	result.dialect = d
+	return result
+}
+
+//hash returns a stable value for fi derived from the seed of pc.
+//pc may be nil.
+func (pc *parseContext) hash(fi fieldIndex) uint64 {
+	seed := ""
+	if pc != nil {
+		seed = pc.seed
+	}
+	return hashSeed(fmt.Sprintf("%v %v", seed, fi))
+}
+
+//isLenient returns whether names may be partial.
+//pc may be nil.
+func (pc *parseContext) isLenient() bool {
+	return pc != nil && pc.lenient
+}
+
+//isCollecting returns whether every error and warning is returned rather than only the first
+//error.
+//pc may be nil.
+func (pc *parseContext) isCollecting() bool {
+	return pc != nil && pc.collect
+}
+
+//random returns a value in [0, n).
+//pc may be nil.
+func (pc *parseContext) random(n uint64) uint64 {
+	if pc == nil || pc.rand == nil {
+		return uint64(rand.Int63n(int64(n)))
+	}
+	return uint64(pc.rand.Int63n(int64(n)))
 }
 
 var fieldSeparatorFunc = func(r rune) bool {
@@ -68,71 +336,445 @@ func MustParse(expression string) Schedule {
 }
 
 func Parse(expression string) (Schedule, error) {
-	//ParseErrors should be returned from this function and no others.
-	fieldStrings, err := getNormalizedFields(expression)
+	return ParseWithSeed(expression, "")
+}
+
+func ParseWithSeed(expression, seed string) (Schedule, error) {
+	return (&Parser{Seed: seed}).Parse(expression)
+}
+
+func parseExpression(expression string, pc *parseContext) (Schedule, error) {
+	d := pc.getDialect()
+	if fields := Fields(expression); d.Directives && len(fields) > 0 {
+		switch strings.ToLower(fields[0]) {
+		case Reboot:
+			if len(fields) == 1 {
+				return NewRebootSchedule(), nil
+			}
+		case Every:
+			return parseIntervalExpression(fields[0], fields[1:])
+		case At:
+			return parseAtExpression(fields[1:])
+		}
+	}
+	fieldStrings, err := getNormalizedFields(expression, d)
 	if err != nil {
-		return nil, newParseError(expression, err.Error())
+		if spans := fieldSpans(expression); len(spans) == 1 {
+			err = asExprError(err).withToken(expression[spans[0][0]:spans[0][1]], spans[0][0])
+		}
+		return nil, err
 	}
 	if len(fieldStrings) == 2 {
-		result, err := parseIntervalExpression(fieldStrings[0], fieldStrings[1])
-		if err != nil {
-			return nil, newParseError(expression, err.Error())
+		return parseIntervalExpression(fieldStrings[0], fieldStrings[1:])
+	}
+	var errs exprErrors
+	if len(Fields(expression)) == 1 {
+		//directive formats are written in the Standard dialect.
+		pc = pc.withDialect(&Standard)
+	} else if err := d.validateQuestion(fieldStrings); err != nil {
+		if !pc.isCollecting() {
+			return nil, err
 		}
-		return result, nil
+		errs = errs.add(err)
 	}
 	s := newSchedule()
+	s.daysOr = pc.getDialect().daysOr(fieldStrings)
 	for i, fieldString := range fieldStrings {
 		fi := fieldIndex(i)
-		nexter, err := parseField(fieldString, fi)
+		nexter, err := parseField(fieldString, fi, pc)
 		if err != nil {
-			return nil, newParseError(expression, err.Error())
+			err = newFieldError(err, expression, fi, d)
+			if !pc.isCollecting() {
+				return nil, err
+			}
+			errs = errs.add(err)
+			continue
 		}
 		s.setNexter(nexter, fi)
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if !s.hasDays() {
+		err := newExprError(KindNotInRange, "%v field: no day in %v occurs in months %v", dom, s.dom, s.month)
+		err = err.withToken(fieldStrings[dom], 0)
+		if parts := FieldParts(fieldStrings[dom]); len(parts) == 1 {
+			err = err.inPart(parts, 0)
+		}
+		err.field = dom
+		return nil, newFieldError(err, expression, dom, d)
+	}
 	return s, nil
 }
 
-func parseIntervalExpression(directive, value string) (Schedule, error) {
-	if strings.ToUpper(directive) != Every {
-		return nil, newDirectiveError(directive)
+//newFieldError returns old, which is in the normalized field fi of expression in d, with its
+//offset relative to expression.
+func newFieldError(old error, expression string, fi fieldIndex, d *Dialect) error {
+	spans := fieldSpans(expression)
+	omitSeconds, _ := d.omittedFields(len(spans))
+	index := int(fi)
+	if omitSeconds {
+		index--
+	}
+	return mapExprErrors(old, func(e *exprError) *exprError {
+		if len(spans) == 1 || index < 0 || index >= len(spans) {
+			//the field was not written in expression.
+			return e.withToken("", invalidValue)
+		}
+		return e.shift(spans[index][0])
+	})
+}
+
+//splitComposite splits expression into the expressions it is a composite of, outside of
+//braces, and returns the separator between them.
+//Semicolon binds the loosest, then KeywordExcept, then KeywordAnd.
+func splitComposite(expression string) ([]string, string, error) {
+	if err := validateBraces(expression); err != nil {
+		return nil, "", err
+	}
+	parts, depth, start := []string{}, 0, 0
+	for i, r := range expression {
+		switch string(r) {
+		case OpenBrace:
+			depth++
+		case CloseBrace:
+			depth--
+		case Semicolon:
+			if depth == 0 {
+				parts = append(parts, expression[start:i])
+				start = i + len(Semicolon)
+			}
+		}
+	}
+	if len(parts) > 0 {
+		parts = append(parts, expression[start:])
+		return parts, Semicolon, validateCompositeParts(parts, Semicolon)
+	}
+	for _, keyword := range []string{KeywordExcept, KeywordAnd} {
+		if parts := splitOnKeyword(expression, keyword); len(parts) > 1 {
+			return parts, keyword, validateCompositeParts(parts, keyword)
+		}
+	}
+	return []string{expression}, "", nil
+}
+
+//splitOnKeyword splits expression on the fields that equal keyword, ignoring case,
+//outside of braces. The parts are trimmed of field separators.
+func splitOnKeyword(expression, keyword string) []string {
+	parts, depth := []string{}, 0
+	start, end := invalidValue, invalidValue
+	part := func() string {
+		if start < 0 {
+			return ""
+		}
+		return expression[start:end]
+	}
+	for _, span := range fieldSpans(expression) {
+		field := expression[span[0]:span[1]]
+		if depth == 0 && strings.EqualFold(field, keyword) {
+			parts = append(parts, part())
+			start = invalidValue
+			continue
+		}
+		depth += strings.Count(field, OpenBrace) - strings.Count(field, CloseBrace)
+		if start < 0 {
+			start = span[0]
+		}
+		end = span[1]
+	}
+	return append(parts, part())
+}
+
+func validateBraces(expression string) error {
+	//opens are the offsets of the open braces that have not been closed.
+	opens := []int{}
+	for i, r := range expression {
+		switch string(r) {
+		case OpenBrace:
+			opens = append(opens, i)
+		case CloseBrace:
+			if len(opens) == 0 {
+				return newExprError(KindComposite, "%q does not have a matching %q", CloseBrace, OpenBrace).withToken(CloseBrace, i)
+			}
+			opens = opens[:len(opens)-1]
+		}
+	}
+	if len(opens) > 0 {
+		return newExprError(KindComposite, "%q does not have a matching %q", OpenBrace, CloseBrace).withToken(OpenBrace, opens[len(opens)-1])
+	}
+	return nil
+}
+
+func validateCompositeParts(parts []string, separator string) error {
+	for _, part := range parts {
+		if len(Fields(part)) == 0 {
+			return newExprError(KindComposite, "%q must be between two expressions", separator).withToken(separator, invalidValue)
+		}
+	}
+	return nil
+}
+
+//isBraced returns whether expression is entirely enclosed in a single pair of braces.
+func isBraced(expression string) bool {
+	if !strings.HasPrefix(expression, OpenBrace) || !strings.HasSuffix(expression, CloseBrace) {
+		return false
+	}
+	depth := 0
+	for i, r := range expression {
+		switch string(r) {
+		case OpenBrace:
+			depth++
+		case CloseBrace:
+			depth--
+		}
+		if depth == 0 && i < len(expression)-len(CloseBrace) {
+			return false
+		}
+	}
+	return true
+}
+
+//splitTimeZoneField removes a first TimeZone field from expression and returns the rest
+//of expression, its offset in expression, and the location of the field, which is nil if
+//there is none.
+func splitTimeZoneField(expression string) (string, *time.Location, int, error) {
+	spans := fieldSpans(expression)
+	if len(spans) == 0 {
+		return expression, nil, 0, nil
+	}
+	first := spans[0]
+	field := expression[first[0]:first[1]]
+	if !strings.HasPrefix(field, TimeZone) {
+		return expression, nil, 0, nil
+	}
+	name := strings.TrimPrefix(field, TimeZone)
+	if name == "" {
+		return "", nil, 0, newExprError(KindTimeZone, "%v must name a time zone", TimeZone).withToken(field, first[0])
 	}
-	duration, err := time.ParseDuration(value)
+	loc, err := time.LoadLocation(name)
 	if err != nil {
-		return nil, fmt.Errorf("%v duration value could not be parsed: %v", Every, err.Error())
+		return "", nil, 0, newExprError(KindTimeZone, "time zone could not be loaded: %v", err.Error()).withToken(field, first[0])
 	}
-	return NewIntervalSchedule(duration), nil
+	return expression[first[1]:], loc, first[1], nil
 }
 
-func getNormalizedFields(expression string) ([]string, error) {
-	fields := Fields(expression)
-	var err error = nil
-	count, err := validateNumberOfFields(fields)
+//splitSplayField removes a trailing splay field, such as "~5m", from expression.
+//The rest of expression is returned without trailing field separators.
+func splitSplayField(expression string) (string, time.Duration, bool, error) {
+	spans := fieldSpans(expression)
+	if len(spans) == 0 {
+		return expression, 0, false, nil
+	}
+	last := spans[len(spans)-1]
+	field := expression[last[0]:last[1]]
+	if !strings.HasPrefix(field, Tilde) {
+		return expression, 0, false, nil
+	}
+	window, err := time.ParseDuration(strings.TrimPrefix(field, Tilde))
 	if err != nil {
-		return nil, err
+		return "", 0, false, newExprError(KindSplay, "%v splay window could not be parsed: %v", Tilde, err.Error()).withToken(field, last[0])
 	}
-	if count == 1 {
-		fields, err = getNormalizedDirectiveFields(fields[0])
+	if window <= 0 {
+		return "", 0, false, newExprError(KindSplay, "%v splay window must be positive", Tilde).withToken(field, last[0])
+	}
+	return strings.TrimRight(expression[:last[0]], TrimCutset), window, true, nil
+}
+
+//splitBoundsFields removes the trailing fields of a BoundedSchedule, such as
+//"[2026-01-01T00:00:00Z,2027-01-01T00:00:00Z)" and "x3", from expression.
+//The returned BoundedSchedule is nil if there are none, and otherwise has no Schedule.
+//The rest of expression is returned without trailing field separators.
+func splitBoundsFields(expression string) (string, *BoundedSchedule, error) {
+	spans := fieldSpans(expression)
+	field := func() string {
+		if len(spans) == 0 {
+			return ""
+		}
+		last := spans[len(spans)-1]
+		return expression[last[0]:last[1]]
+	}
+	var result *BoundedSchedule
+	if f := field(); strings.HasPrefix(f, Runs) {
+		maxRuns, err := strconv.Atoi(strings.TrimPrefix(f, Runs))
+		if err != nil || maxRuns <= 0 {
+			return "", nil, newExprError(KindBounds, "%v maximum runs must be a positive decimal integer", Runs).withToken(f, spans[len(spans)-1][0])
+		}
+		result = &BoundedSchedule{MaxRuns: maxRuns}
+		spans = spans[:len(spans)-1]
+	}
+	if f := field(); strings.HasPrefix(f, OpenBracket) {
+		start, end, err := parseWindowField(f)
 		if err != nil {
-			return nil, err
+			return "", nil, asExprError(err).withToken(f, spans[len(spans)-1][0])
+		}
+		if result == nil {
+			result = &BoundedSchedule{}
+		}
+		result.Start, result.End = start, end
+		spans = spans[:len(spans)-1]
+	}
+	if result == nil {
+		return expression, nil, nil
+	}
+	if len(spans) == 0 {
+		return "", result, nil
+	}
+	return expression[:spans[len(spans)-1][1]], result, nil
+}
+
+//parseWindowField parses field in the form "[start,end)" where start and end are either
+//empty or RFC 3339 times.
+func parseWindowField(field string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	parts := strings.Split(strings.TrimPrefix(field, OpenBracket), Comma)
+	if !strings.HasSuffix(field, CloseParen) || len(parts) != 2 {
+		return start, end, newExprError(KindBounds, "window %q must be in the form %v%v%v", field, OpenBracket+"start", Comma, "end"+CloseParen)
+	}
+	parts[1] = strings.TrimSuffix(parts[1], CloseParen)
+	var err error
+	if parts[0] != "" {
+		if start, err = time.Parse(time.RFC3339, parts[0]); err != nil {
+			return start, end, newExprError(KindBounds, "window start could not be parsed: %v", err.Error())
+		}
+	}
+	if parts[1] != "" {
+		if end, err = time.Parse(time.RFC3339, parts[1]); err != nil {
+			return start, end, newExprError(KindBounds, "window end could not be parsed: %v", err.Error())
 		}
 	}
-	if count == 5 {
-		fields = append([]string{fmt.Sprint(MinSecond)}, fields...)
-		count++
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return start, end, newExprError(KindBounds, "window start must be before end")
 	}
-	if count == 6 {
-		fields = append(fields, Asterisk)
-		count++
+	return start, end, nil
+}
+
+//parseAtExpression parses the values after an At directive, which is a single RFC 3339 time.
+func parseAtExpression(values []string) (Schedule, error) {
+	if len(values) != 1 {
+		return nil, newExprError(KindAt, "%v must have a single time value", At).withToken(At, invalidValue)
+	}
+	t, err := time.Parse(time.RFC3339, values[0])
+	if err != nil {
+		return nil, newExprError(KindAt, "%v time value could not be parsed: %v", At, err.Error()).withToken(values[0], invalidValue)
 	}
-	return fields, nil
+	return NewAtSchedule(t), nil
 }
 
-func validateNumberOfFields(fields []string) (int, error) {
-	count := len(fields)
-	if count != 1 && count != 2 && count != 5 && count != 6 && count != 7 {
-		return 0, fmt.Errorf("number of fields must be 1, 2, 5, 6, or 7")
+//parseIntervalExpression parses the values after an Every directive. values are a
+//duration, optionally followed by From and a time of day or Offset and a duration.
+func parseIntervalExpression(directive string, values []string) (Schedule, error) {
+	if strings.ToLower(directive) != Every {
+		return nil, newDirectiveError(directive)
+	}
+	if len(values) != 1 && len(values) != 3 {
+		return nil, newExprError(KindInterval, "%v must have a duration value optionally followed by %q or %q and a value", Every, From, Offset).withToken(directive, invalidValue)
+	}
+	interval, err := parseEveryDuration(values[0])
+	if err != nil {
+		return nil, newExprError(KindInterval, "%v duration value could not be parsed: %v", Every, err.Error()).withToken(values[0], invalidValue)
+	}
+	if interval <= 0 {
+		return nil, newExprError(KindInterval, "%v duration value must be positive", Every).withToken(values[0], invalidValue)
+	}
+	if len(values) == 1 {
+		return NewIntervalSchedule(interval), nil
+	}
+	var offset time.Duration
+	switch strings.ToLower(values[1]) {
+	case From:
+		offset, err = parseTimeOfDay(values[2])
+	case Offset:
+		offset, err = parseEveryDuration(values[2])
+	default:
+		return nil, newExprError(KindInterval, "%v anchor %q must be %q or %q", Every, values[1], From, Offset).withToken(values[1], invalidValue)
+	}
+	if err != nil {
+		return nil, newExprError(KindInterval, "%v %v value could not be parsed: %v", Every, strings.ToLower(values[1]), err.Error()).withToken(values[2], invalidValue)
 	}
-	return count, nil
+	return NewAnchoredIntervalSchedule(interval, offset), nil
+}
+
+//everyUnits are the units accepted in Every durations that time.ParseDuration does not.
+var everyUnits = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+//parseEveryDuration parses value like time.ParseDuration but also accepts days with
+//"d" and weeks with "w", as in "1d" and "1w2d12h".
+func parseEveryDuration(value string) (time.Duration, error) {
+	sign, rest := time.Duration(1), value
+	if strings.HasPrefix(rest, Hyphen) {
+		sign, rest = -1, rest[1:]
+	}
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	result, others := time.Duration(0), ""
+	for len(rest) > 0 {
+		numberEnd := strings.IndexFunc(rest, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if numberEnd < 0 {
+			//a number without a unit is left to time.ParseDuration, which accepts "0".
+			others += rest
+			break
+		}
+		if numberEnd == 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		unitEnd := strings.IndexFunc(rest[numberEnd:], func(r rune) bool {
+			return (r >= '0' && r <= '9') || r == '.'
+		})
+		if unitEnd < 0 {
+			unitEnd = len(rest) - numberEnd
+		}
+		number, unit := rest[:numberEnd], rest[numberEnd:numberEnd+unitEnd]
+		rest = rest[numberEnd+unitEnd:]
+		scale, ok := everyUnits[unit]
+		if !ok {
+			others += number + unit
+			continue
+		}
+		count, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		result += time.Duration(count * float64(scale))
+	}
+	if others != "" {
+		d, err := time.ParseDuration(others)
+		if err != nil {
+			return 0, err
+		}
+		result += d
+	}
+	return sign * result, nil
+}
+
+//parseTimeOfDay parses value in the form "15:04" or "15:04:05" as a duration after midnight.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return wallClock(t).Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)), nil
+		}
+	}
+	return 0, fmt.Errorf("time of day %q must be in the form HH:MM or HH:MM:SS", value)
+}
+
+func getNormalizedFields(expression string, d *Dialect) ([]string, error) {
+	fields := Fields(expression)
+	count, err := d.validateNumberOfFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	if count == 1 {
+		return getNormalizedDirectiveFields(fields[0])
+	}
+	if count == 2 {
+		return fields, nil
+	}
+	return d.normalizeFields(fields), nil
 }
 
 func getNormalizedDirectiveFields(directive string) ([]string, error) {
@@ -155,6 +797,16 @@ func getNormalizedDirectiveFields(directive string) ([]string, error) {
 		format = MinutelyFormat
 	case Secondly:
 		format = SecondlyFormat
+	case Midnight:
+		format = MidnightFormat
+	case Quarterly:
+		format = QuarterlyFormat
+	case Weekdays:
+		format = WeekdaysFormat
+	case Workdays:
+		format = WorkdaysFormat
+	case Weekends:
+		format = WeekendsFormat
 	}
 	if format == "" {
 		return nil, newDirectiveError(directive)
@@ -162,59 +814,107 @@ func getNormalizedDirectiveFields(directive string) ([]string, error) {
 	return Fields(format), nil
 }
 
+func isBuiltInDirective(directive string) bool {
+	switch strings.ToLower(directive) {
+	case Every, Reboot, At:
+		return true
+	}
+	_, err := getNormalizedDirectiveFields(directive)
+	return err == nil
+}
+
 func newDirectiveError(directive string) error {
-	return fmt.Errorf("the directive %q is not recognized", directive)
+	return newExprError(KindDirective, "the directive %q is not recognized", directive).withToken(directive, invalidValue)
 }
 
-func parseField(field string, fi fieldIndex) (nexter interface{}, err error) {
+func parseField(field string, fi fieldIndex, pc *parseContext) (nexter interface{}, err error) {
 	parts := FieldParts(field)
 	if fi.isDateField() {
-		nexter, err = parseDateFieldNexterParts(parts, fi)
+		nexter, err = parseDateFieldNexterParts(parts, fi, pc)
 	} else {
-		nexter, err = parseFieldNexterParts(parts, fi)
+		nexter, err = parseFieldNexterParts(parts, fi, pc)
 	}
 	if err != nil {
-		err = fmt.Errorf("%v field: %v", fi, err.Error())
+		err = mapExprErrors(err, func(e *exprError) *exprError {
+			e = wrapExprError(e, "%v field: %v", fi, e.desc)
+			if len(parts) == 1 && e.part < 0 {
+				e = e.inPart(parts, 0)
+			}
+			e.field = fi
+			return e
+		})
 		nexter = nil
 	}
 	return
 }
 
-func parseDateFieldNexterParts(parts []string, fi fieldIndex) (dateFieldNexter, error) {
+func parseDateFieldNexterParts(parts []string, fi fieldIndex, pc *parseContext) (dateFieldNexter, error) {
 	if len(parts) == 1 {
-		return parseDateFieldNexterPart(parts[0], fi)
+		nexter, err := parseDateFieldNexterPart(parts[0], fi, pc)
+		if err == nil {
+			err = pc.partWarning(nexter, parts[0], fi)
+		}
+		return nexter, err
 	}
 	result := multiDateFieldNexter(make([]dateFieldNexter, 0, len(parts)))
+	var errs exprErrors
 	for i, part := range parts {
-		nexter, err := parseDateFieldNexterPart(part, fi)
+		nexter, err := parseDateFieldNexterPart(part, fi, pc)
+		if err == nil {
+			err = pc.partWarning(nexter, part, fi)
+		}
 		if err != nil {
-			return nil, newPartError(i, err)
+			err = newPartError(i, err).inPart(parts, i)
+			if !pc.isCollecting() {
+				return nil, err
+			}
+			errs = errs.add(err)
+			continue
 		}
 		result = append(result, nexter)
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return result, nil
 }
 
-func parseFieldNexterParts(parts []string, fi fieldIndex) (fieldNexter, error) {
+func parseFieldNexterParts(parts []string, fi fieldIndex, pc *parseContext) (fieldNexter, error) {
 	if len(parts) == 1 {
-		return parseFieldNexterPart(parts[0], fi)
+		nexter, err := parseFieldNexterPart(parts[0], fi, pc)
+		if err == nil {
+			err = pc.partWarning(nexter, parts[0], fi)
+		}
+		return nexter, err
 	}
 	result := multiNexter(make([]fieldNexter, 0, len(parts)))
+	var errs exprErrors
 	for i, part := range parts {
-		nexter, err := parseFieldNexterPart(part, fi)
+		nexter, err := parseFieldNexterPart(part, fi, pc)
+		if err == nil {
+			err = pc.partWarning(nexter, part, fi)
+		}
 		if err != nil {
-			return nil, newPartError(i, err)
+			err = newPartError(i, err).inPart(parts, i)
+			if !pc.isCollecting() {
+				return nil, err
+			}
+			errs = errs.add(err)
+			continue
 		}
 		result = append(result, nexter)
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return result, nil
 }
 
-func newPartError(index int, old error) error {
-	return fmt.Errorf("part %v: %v", index+1, old.Error())
+func newPartError(index int, old error) *exprError {
+	return wrapExprError(old, "part %v: %v", index+1, old.Error())
 }
 
-func parseDateFieldNexterPart(part string, fi fieldIndex) (dateFieldNexter, error) {
+func parseDateFieldNexterPart(part string, fi fieldIndex, pc *parseContext) (dateFieldNexter, error) {
 	if len(part) == 0 {
 		return nil, errEmpty
 	}
@@ -226,7 +926,7 @@ func parseDateFieldNexterPart(part string, fi fieldIndex) (dateFieldNexter, erro
 		modIndex = len(part)
 	}
 	fieldNexterPart := part[:modIndex]
-	fn, err := parseFieldNexterPart(fieldNexterPart, fi)
+	fn, err := parseFieldNexterPart(fieldNexterPart, fi, pc)
 	if fi == dom {
 		if err != nil {
 			if err == errEmpty {
@@ -235,38 +935,68 @@ func parseDateFieldNexterPart(part string, fi fieldIndex) (dateFieldNexter, erro
 				return nil, newBeforeModifierError(fieldNexterPart, err)
 			}
 		}
-		return parseDomDateField(fn, modifiers)
+		result, err := parseDomDateField(fn, modifiers)
+		if err != nil {
+			return nil, newModifierError(err, modifiers, modIndex)
+		}
+		return result, nil
 	} else if fi == dow {
 		if err != nil {
 			return nil, newBeforeModifierError(fieldNexterPart, err)
 		}
-		return parseDowDateField(fn, modifiers)
+		result, err := parseDowDateField(fn, modifiers)
+		if err != nil {
+			return nil, newModifierError(err, modifiers, modIndex)
+		}
+		return result, nil
 	}
 	//this should never happen.
 	return nil, fmt.Errorf("invalid fieldIndex %v for date field parsing", fi)
 }
 
 func newBeforeModifierError(before string, old error) error {
-	return fmt.Errorf("section before modifiers %q %v", before, old.Error())
+	return wrapExprError(old, "section before modifiers %q %v", before, old.Error())
+}
+
+//newModifierError returns old with the token modifiers at offset if it is an error in the
+//modifiers.
+func newModifierError(old error, modifiers string, offset int) error {
+	e := asExprError(old)
+	if e.kind != KindModifier || modifiers == "" || e.token != "" {
+		return old
+	}
+	return e.withToken(modifiers, offset)
 }
 
 //nexter may be nil.
 func parseDomDateField(nexter fieldNexter, modifiers string) (*domFieldNexter, error) {
 	modifiers, hasLast := hasAndRemoveModifier(modifiers, Last)
 	modifiers, hasWeekday := hasAndRemoveModifier(modifiers, Weekday)
+	offset := 0
+	if hasLast && strings.HasPrefix(modifiers, Hyphen) {
+		var err error
+		offset, err = strconv.Atoi(modifiers[len(Hyphen):])
+		if err != nil {
+			return nil, newExprError(KindModifier, "value after %q %v", Last+Hyphen, errParseInteger)
+		}
+		if offset <= 0 {
+			return nil, newExprError(KindModifier, "invalid offset for %q modifier", Last)
+		}
+		modifiers = ""
+	}
 	if len(modifiers) != 0 {
 		return nil, newUnknownModifierError(modifiers)
 	}
 	if hasLast && hasWeekday && nexter != nil {
-		return nil, fmt.Errorf("modifiers %q and %q used together cannot have a value before them", Last, Weekday)
+		return nil, newExprError(KindModifier, "modifiers %q and %q used together cannot have a value before them", Last, Weekday)
 	}
 	if !hasLast && !hasWeekday && nexter == nil {
 		return nil, errEmpty
 	}
 	if _, ok := nexter.(valueNexter); hasWeekday && !ok && !hasLast {
-		return nil, fmt.Errorf("modifier %q can only be used with a single, static value", Weekday)
+		return nil, newExprError(KindModifier, "modifier %q can only be used with a single, static value", Weekday)
 	}
-	return newDomFieldNexter(nexter, hasLast, hasWeekday)
+	return newDomFieldNexter(nexter, hasLast, hasWeekday, offset)
 }
 
 //modifiers must start with valid modifier. ie. "2#4" is invalid.
@@ -275,11 +1005,11 @@ func parseDowDateField(nexter fieldNexter, modifiers string) (*dowFieldNexter, e
 	modifiers, hasHash := hasAndRemoveModifier(modifiers, Hash)
 	if hasHash {
 		if _, hasLast := hasAndRemoveModifier(modifiers, Last); hasLast {
-			return nil, fmt.Errorf("cannot have %q and %q modifiers together", Last, Hash)
+			return nil, newExprError(KindModifier, "cannot have %q and %q modifiers together", Last, Hash)
 		}
 		number, err := strconv.Atoi(modifiers)
 		if err != nil {
-			return nil, fmt.Errorf("value after %q %v", Hash, errParseInteger)
+			return nil, newExprError(KindModifier, "value after %q %v", Hash, errParseInteger)
 		}
 		return newDowFieldNexter(nexter, false, number, true)
 	}
@@ -291,7 +1021,7 @@ func parseDowDateField(nexter fieldNexter, modifiers string) (*dowFieldNexter, e
 }
 
 func newUnknownModifierError(modifiers string) error {
-	return fmt.Errorf("unknown modifier %q", modifiers)
+	return newExprError(KindModifier, "unknown modifier %q", modifiers).withToken(modifiers, invalidValue)
 }
 
 func hasAndRemoveModifier(modifiers, modifier string) (string, bool) {
@@ -305,89 +1035,220 @@ func hasAndRemoveModifier(modifiers, modifier string) (string, bool) {
 	return modifiers[:index] + modifiers[index+len(modifier):], true
 }
 
-func parseFieldNexterPart(part string, fi fieldIndex) (fieldNexter, error) {
+func parseFieldNexterPart(part string, fi fieldIndex, pc *parseContext) (fieldNexter, error) {
 	if len(part) == 0 {
 		return nil, errEmpty
 	}
+	if strings.HasPrefix(strings.ToUpper(part), Hashed) {
+		return parseSpreadNexter(part[len(Hashed):], fi, pc, Hashed, func(n uint64) uint64 {
+			return pc.hash(fi) % n
+		})
+	}
+	if strings.HasPrefix(strings.ToUpper(part), Random) {
+		return parseSpreadNexter(part[len(Random):], fi, pc, Random, pc.random)
+	}
 	slashIndex := strings.Index(part, Slash)
 	if slashIndex < 0 {
-		return parseRangeOrConstantNexter(part, fi)
+		return parseRangeOrConstantNexter(part, fi, pc)
 	}
 	if slashIndex == 0 {
-		return nil, fmt.Errorf("value before step %v", errEmpty.Error())
+		return nil, newExprError(KindEmpty, "value before step %v", errEmpty.Error()).withToken(Slash, 0)
 	}
-	rn, err := parseRangeNexter(part[:slashIndex], fi)
-	if err != nil {
-		if err == errNoHyphen {
-			return nil, fmt.Errorf("invalid required range before step value")
+	rn, err := parseRangeNexter(part[:slashIndex], fi, pc)
+	if err == errNoHyphen {
+		//a single starting value, such as 5/15, ranges to the end of the field.
+		var min int
+		min, err = parseSingleValue(part[:slashIndex], fi, pc)
+		if err != nil {
+			return nil, wrapExprError(err, "value before step %v", err.Error())
 		}
+		rn = newRangeNexter(min, fi.fieldRange().max)
+	}
+	if err != nil {
 		return nil, err
 	}
+
 	inc, err := parseIncValue(part[slashIndex+1:])
 	if err != nil {
-		return nil, fmt.Errorf("invalid required step value: %v", err.Error())
+		return nil, wrapExprError(err, "invalid required step value: %v", err.Error()).withToken(part[slashIndex:], slashIndex)
+	}
+	if rn.isWrapped() {
+		return newWrapRangeDivNexter(rn, inc, fi.fieldRange()), nil
 	}
 	return newRangeDivNexter(rn, inc), nil
 }
 
-func parseRangeOrConstantNexter(part string, fi fieldIndex) (fieldNexter, error) {
-	part = convertPossibleAnyToRange(part, fi)
+//parseSpreadNexter parses the part of a field after token, which is either Hashed or Random.
+//That is one of "", "/15", "(0-29)", or "(0-29)/15".
+//value must return a value in [0, n) and determines where in the range the result falls.
+func parseSpreadNexter(part string, fi fieldIndex, pc *parseContext, token string, value func(n uint64) uint64) (fieldNexter, error) {
+	inc := 0
+	if slashIndex := strings.Index(part, Slash); slashIndex >= 0 {
+		var err error
+		inc, err = parseIncValue(part[slashIndex+1:])
+		if err != nil {
+			return nil, wrapExprError(err, "invalid %q step value: %v", token, err.Error()).withToken(part[slashIndex:], invalidValue)
+		}
+		part = part[:slashIndex]
+	}
+	min, max, err := parseSpreadRange(part, fi, pc, token)
+	if err != nil {
+		return nil, err
+	}
+	span := uint64(max - min + 1)
+	if inc == 0 || uint64(inc) >= span {
+		return newValueNexter(min + int(value(span))), nil
+	}
+	start := min + int(value(uint64(inc)))
+	return newRangeDivNexter(newRangeNexter(start, max), inc), nil
+}
+
+func parseSpreadRange(part string, fi fieldIndex, pc *parseContext, token string) (int, int, error) {
+	if len(part) == 0 {
+		if fi == year {
+			return invalidValue, invalidValue, newExprError(KindRange, "%q requires an explicit range in the %v field", token, fi).withToken(token, invalidValue)
+		}
+		if fi == dom {
+			//days after the 28th do not exist in every month.
+			return MinDom, 28, nil
+		}
+		fr := fi.fieldRange()
+		return fr.min, fr.max, nil
+	}
+	if !strings.HasPrefix(part, OpenParen) || !strings.HasSuffix(part, CloseParen) {
+		return invalidValue, invalidValue, newExprError(KindRange, "invalid %q range %q", token, part).withToken(part, invalidValue)
+	}
+	rn, err := parseRangeNexter(part[len(OpenParen):len(part)-len(CloseParen)], fi, pc)
+	if err != nil {
+		if err == errNoHyphen {
+			return invalidValue, invalidValue, newExprError(KindRange, "invalid %q range %q", token, part).withToken(part, invalidValue)
+		}
+		//offsets in the range are not relative to the part.
+		e := wrapExprError(err, "%q range %v", token, err.Error())
+		return invalidValue, invalidValue, e.withToken(e.token, invalidValue)
+	}
+	if rn.isWrapped() {
+		return invalidValue, invalidValue, newExprError(KindRange, "%q range cannot wrap around", token).withToken(part, invalidValue)
+	}
+	return rn.min, rn.max, nil
+}
+
+func parseRangeOrConstantNexter(part string, fi fieldIndex, pc *parseContext) (fieldNexter, error) {
+	part = convertPossibleAnyToRange(part, fi, pc)
 	if strings.Contains(part, Hyphen) {
-		return parseRangeNexter(part, fi)
+		rn, err := parseRangeNexter(part, fi, pc)
+		if err != nil {
+			return nil, err
+		}
+		if rn.isWrapped() {
+			return newWrapRangeNexter(rn, fi.fieldRange()), nil
+		}
+		return rn, nil
 	}
-	return parseValueNexter(part, fi)
+	return parseValueNexter(part, fi, pc)
 }
 
-func parseRangeNexter(part string, fi fieldIndex) (*rangeNexter, error) {
-	part = convertPossibleAnyToRange(part, fi)
+//parseRangeNexter returns a range whose min is greater than its max if it wraps around
+//from the end of the field to the start.
+func parseRangeNexter(part string, fi fieldIndex, pc *parseContext) (*rangeNexter, error) {
+	part = convertPossibleAnyToRange(part, fi, pc)
 	hyphenIndex := strings.Index(part, Hyphen)
 	if hyphenIndex < 0 {
 		return nil, errNoHyphen
 	}
-	min, err := parseSingleValue(part[:hyphenIndex], fi)
+	left, right := part[:hyphenIndex], part[hyphenIndex+1:]
+	min, err := parseSingleValue(left, fi, pc)
 	if err != nil {
-		return nil, fmt.Errorf("left side of range %v", err.Error())
+		return nil, wrapExprError(err, "left side of range %v", err.Error()).withToken(left, 0)
 	}
-	max, err := parseSingleValue(part[hyphenIndex+1:], fi)
+	max, err := parseSingleValue(right, fi, pc)
 	if err != nil {
-		return nil, fmt.Errorf("right side of range %v", err.Error())
+		return nil, wrapExprError(err, "right side of range %v", err.Error()).withToken(right, hyphenIndex+1)
+	}
+	if fi == dow && min == max && !isSundaySeven(left, pc) && isSundaySeven(right, pc) {
+		//0-7 is every day of the week.
+		max = MaxDow
 	}
-	if min >= max {
-		return nil, fmt.Errorf("left side value of range must be strictly less than right side value")
+	if min == max {
+		return nil, newExprError(KindRange, "left side value of range must not equal right side value").withToken(part, 0)
+	}
+	if min > max && fi == year {
+		return nil, newExprError(KindRange, "left side value of range must be less than right side value in the %v field", fi)
 	}
 	return newRangeNexter(min, max), nil
 }
 
-func convertPossibleAnyToRange(part string, fi fieldIndex) string {
+func convertPossibleAnyToRange(part string, fi fieldIndex, pc *parseContext) string {
 	if fi.isDateField() {
 		part = strings.Replace(part, Question, Asterisk, -1)
 	}
+	if fi == dow {
+		return strings.Replace(part, Asterisk, pc.getDialect().dowRangeString(), -1)
+	}
 	return strings.Replace(part, Asterisk, fi.rangeString(), -1)
 }
 
-func parseValueNexter(part string, fi fieldIndex) (valueNexter, error) {
-	value, err := parseSingleValue(part, fi)
+func parseValueNexter(part string, fi fieldIndex, pc *parseContext) (valueNexter, error) {
+	value, err := parseSingleValue(part, fi, pc)
 	if err != nil {
 		return valueNexter(invalidValue), err
 	}
 	return valueNexter(value), nil
 }
 
-func parseSingleValue(value string, fi fieldIndex) (int, error) {
-	value = convertPossibleMonthDowToInteger(value, fi)
-	result, err := strconv.Atoi(value)
+func parseSingleValue(value string, fi fieldIndex, pc *parseContext) (int, error) {
+	converted := convertPossibleMonthDowToInteger(value, fi)
+	result, err := strconv.Atoi(converted)
 	if err != nil {
 		if fi == month || fi == dow {
-			return invalidValue, errParseIntegerAlias
+			return invalidValue, errParseIntegerAlias.withToken(value, invalidValue)
 		}
-		return invalidValue, errParseInteger
+		return invalidValue, errParseInteger.withToken(value, invalidValue)
+	}
+	if converted != value && !pc.isLenient() && !isAlias(value, fi) {
+		return invalidValue, errParseIntegerAlias.withToken(value, invalidValue)
+	}
+	if fi == dow && converted == value && pc.getDialect().SundayIsOne {
+		//aliases are already converted to 0-6.
+		result--
+	} else if fi == dow && isSundaySeven(value, pc) {
+		result = int(time.Sunday)
 	}
 	if !fi.isInRange(result) {
-		return invalidValue, errNotInRange
+		return invalidValue, errNotInRange.withToken(value, invalidValue)
 	}
 	return result, nil
 }
 
+//isSundaySeven returns whether value is the alternate value 7 for Sunday.
+func isSundaySeven(value string, pc *parseContext) bool {
+	return value == fmt.Sprint(MaxDow+1) && !pc.getDialect().SundayIsOne
+}
+
+//isAlias returns whether value is the three character abbreviation or the full name of
+//a month or day of week.
+func isAlias(value string, fi fieldIndex) bool {
+	names := []string{}
+	if fi == month {
+		for m := time.January; m <= time.December; m++ {
+			names = append(names, m.String())
+		}
+	}
+	if fi == dow {
+		for w := time.Sunday; w <= time.Saturday; w++ {
+			names = append(names, w.String())
+		}
+	}
+	value = strings.ToUpper(value)
+	for _, name := range names {
+		name = strings.ToUpper(name)
+		if value == name || value == name[:3] {
+			return true
+		}
+	}
+	return false
+}
+
 func convertPossibleMonthDowToInteger(value string, fi fieldIndex) string {
 	if fi == month {
 		return convertMonthToInteger(value)
@@ -426,11 +1287,11 @@ func convertDowToInteger(value string) string {
 
 func parseIncValue(value string) (int, error) {
 	if len(value) == 0 {
-		return invalidValue, fmt.Errorf("step value %v", errEmpty.Error())
+		return invalidValue, newExprError(KindStep, "step value %v", errEmpty.Error())
 	}
 	inc, err := strconv.Atoi(value)
 	if err != nil || inc <= 0 {
-		return invalidValue, fmt.Errorf("step value must be a positive decimal integer")
+		return invalidValue, newExprError(KindStep, "step value must be a positive decimal integer").withToken(value, invalidValue)
 	}
 	return inc, nil
 }
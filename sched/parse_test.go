@@ -2,6 +2,7 @@ package sched
 
 import (
 	"fmt"
+	"math/rand"
 	"reflect"
 	"strings"
 	"testing"
@@ -99,7 +100,7 @@ func TestGetNormalizedFields(t *testing.T) {
 		{"a b c d e f g h", nil, true},
 	}
 	for _, test := range tests {
-		result, err := getNormalizedFields(test.expression)
+		result, err := getNormalizedFields(test.expression, &Standard)
 		if (err != nil) != test.hasError {
 			t.Errorf("getNormalizedFields(%v) error = %v WANT to have an error %v", test.expression, err, test.hasError)
 		}
@@ -112,35 +113,6 @@ func TestGetNormalizedFields(t *testing.T) {
 	}
 }
 
-func TestValidateNumberOfFields(t *testing.T) {
-	errString := "number of fields must be 1, 2, 5, 6, or 7"
-	tests := []struct {
-		fields []string
-		count  int
-		err    string
-	}{
-		{nil, 0, errString},
-		{[]string{}, 0, errString},
-		{make([]string, 1), 1, ""},
-		{make([]string, 2), 2, ""},
-		{make([]string, 3), 0, errString},
-		{make([]string, 4), 0, errString},
-		{make([]string, 5), 5, ""},
-		{make([]string, 6), 6, ""},
-		{make([]string, 7), 7, ""},
-		{make([]string, 8), 0, errString},
-	}
-	for _, test := range tests {
-		count, err := validateNumberOfFields(test.fields)
-		if (err != nil || test.err != "") && err.Error() != test.err {
-			t.Errorf("validateNumberOfFields(%v) error = %v WANT %v", test.fields, err, test.err)
-		}
-		if count != test.count {
-			t.Errorf("validateNumberOfFields(%v) count = %v WANT %v", test.fields, count, test.count)
-		}
-	}
-}
-
 func TestGetNormalizedDirectiveFields(t *testing.T) {
 	tests := []struct {
 		directive string
@@ -156,6 +128,11 @@ func TestGetNormalizedDirectiveFields(t *testing.T) {
 		{Hourly, Fields(HourlyFormat), ""},
 		{Minutely, Fields(MinutelyFormat), ""},
 		{Secondly, Fields(SecondlyFormat), ""},
+		{Midnight, Fields(MidnightFormat), ""},
+		{Quarterly, Fields(QuarterlyFormat), ""},
+		{Weekdays, Fields(WeekdaysFormat), ""},
+		{Workdays, Fields(WorkdaysFormat), ""},
+		{Weekends, Fields(WeekendsFormat), ""},
 		{"@reboot", nil, `the directive "@reboot" is not recognized`},
 	}
 	for _, test := range tests {
@@ -194,7 +171,7 @@ func TestParseDateFieldNexterParts(t *testing.T) {
 		{[]string{Asterisk, Asterisk}, dow, reflect.TypeOf(multiDateFieldNexter{}), ""},
 	}
 	for _, test := range tests {
-		result, err := parseDateFieldNexterParts(test.parts, test.fieldIndex)
+		result, err := parseDateFieldNexterParts(test.parts, test.fieldIndex, nil)
 		if (err != nil || test.err != "") && err.Error() != test.err {
 			t.Errorf("parseDateFieldNexterParts(%v, %v) error = %v WANT %v", test.parts, test.fieldIndex, err, test.err)
 		}
@@ -220,7 +197,7 @@ func TestParseFieldNexterParts(t *testing.T) {
 		{[]string{Asterisk, Asterisk}, second, reflect.TypeOf(multiNexter{}), ""},
 	}
 	for _, test := range tests {
-		result, err := parseFieldNexterParts(test.parts, test.fieldIndex)
+		result, err := parseFieldNexterParts(test.parts, test.fieldIndex, nil)
 		if (err != nil || test.err != "") && err.Error() != test.err {
 			t.Errorf("parseFieldNexterParts(%v, %v) error = %v WANT %v", test.parts, test.fieldIndex, err, test.err)
 		}
@@ -255,11 +232,13 @@ func TestParseDateFieldNexterPart(t *testing.T) {
 		{"", dow, nil, errEmpty.Error()},
 		{Asterisk, year, nil, "invalid fieldIndex year for date field parsing"},
 		//good
-		{Last, dom, &domFieldNexter{nil, true, false}, ""},
-		{Last + Weekday, dom, &domFieldNexter{nil, true, true}, ""},
+		{Last, dom, &domFieldNexter{nil, true, false, 0}, ""},
+		{Last + Weekday, dom, &domFieldNexter{nil, true, true, 0}, ""},
+		{Last + "-3", dom, &domFieldNexter{nil, true, false, 3}, ""},
+		{Last + "-3" + Weekday, dom, &domFieldNexter{nil, true, true, 3}, ""},
 	}
 	for _, test := range tests {
-		result, err := parseDateFieldNexterPart(test.part, test.index)
+		result, err := parseDateFieldNexterPart(test.part, test.index, nil)
 		if (err != nil || test.err != "") && err.Error() != test.err {
 			t.Errorf("parseDateFieldNexterPart(%q, %v) error = %v WANT %v", test.part, test.index, err, test.err)
 		}
@@ -301,6 +280,14 @@ func TestParseDomDateField(t *testing.T) {
 		{Weekday, valueNexter(2), false, true, ""},
 		{Last + Weekday, nil, true, true, ""},
 		{Weekday + Last, nil, true, true, ""},
+		{Last + "-1", nil, true, false, ""},
+		{Last + "-30" + Weekday, nil, true, true, ""},
+		//bad offsets
+		{Last + "-", nil, false, false, `value after "L-" must be a decimal integer`},
+		{Last + "-a", nil, false, false, `value after "L-" must be a decimal integer`},
+		{Last + "-0", nil, false, false, `invalid offset for "L" modifier`},
+		{Last + "-31", nil, false, false, `invalid offset for "L" modifier`},
+		{Weekday + "-3", valueNexter(4), false, false, `unknown modifier "-3"`},
 	}
 	for _, test := range tests {
 		dfn, err := parseDomDateField(test.fieldNexter, test.modifiers)
@@ -327,7 +314,8 @@ func TestParseDowDateField(t *testing.T) {
 		err        string
 	}{
 		{Hash + "6", nil, false, invalidValue, `invalid value for "#" modifier`},
-		{Hash + "-1", nil, false, invalidValue, `invalid value for "#" modifier`},
+		{Hash + "-6", nil, false, invalidValue, `invalid value for "#" modifier`},
+		{Hash + "0", nil, false, invalidValue, `invalid value for "#" modifier`},
 		{Hash + "a", nil, false, invalidValue, `value after "#" ` + errParseInteger.Error()},
 		{Last + Hash, nil, false, invalidValue, `cannot have "L" and "#" modifiers together`},
 		{Hash + Last, nil, false, invalidValue, `cannot have "L" and "#" modifiers together`},
@@ -335,6 +323,7 @@ func TestParseDowDateField(t *testing.T) {
 		{"a", nil, false, invalidValue, `unknown modifier "a"`},
 		{Last, nil, true, invalidValue, ""},
 		{Hash + "2", nil, false, 2, ""},
+		{Hash + "-1", nil, false, 1, ""},
 		{Last, nil, true, invalidValue, ""},
 		{"", valueNexter(1), false, invalidValue, ""},
 	}
@@ -393,18 +382,23 @@ func TestParseFieldNexterPart_errors(t *testing.T) {
 		{"JAN   ", month},
 		{"JAN", second},
 		{"/2", second},
-		{"3/2", second},
+		{"a/2", second},
+		{"60/2", second},
 		{"14-16/a", second},
 		{"14-16/-1", second},
 		{"15-34/", second},
-		{"23-12", second},
+		{"23-23", second},
+		{"2027-2026/2", year},
+		{"2027-2026", year},
+		{"Janu", month},
+		{"THURS", dow},
 		{Last, second},
 		{Weekday, second},
 		{Last + Weekday, second},
 		{"2-34#5", second},
 	}
 	for _, test := range tests {
-		result, err := parseFieldNexterPart(test.value, test.fi)
+		result, err := parseFieldNexterPart(test.value, test.fi, nil)
 		if err == nil {
 			t.Errorf("parseFieldNexterPart(%v, %v) = %v, %v WANT nil, non-nil", test.value, test.fi, result, err)
 		}
@@ -414,7 +408,7 @@ func TestParseFieldNexterPart_errors(t *testing.T) {
 func TestParseFieldNexterPart_valueNexter(t *testing.T) {
 	value := "2026"
 	fi := year
-	result, err := parseFieldNexterPart(value, fi)
+	result, err := parseFieldNexterPart(value, fi, nil)
 	want := valueNexter(2026)
 	if err != nil || result != want {
 		t.Errorf("parseFieldNexterPart(%v, %v) = %v, %v WANT %v, %v", value, fi, result, err, want, nil)
@@ -424,17 +418,38 @@ func TestParseFieldNexterPart_valueNexter(t *testing.T) {
 func TestParseFieldNexterPart_rangeNexter(t *testing.T) {
 	value := "2-8"
 	fi := month
-	result, err := parseFieldNexterPart(value, fi)
+	result, err := parseFieldNexterPart(value, fi, nil)
 	want := rangeNexter{2, 8}
 	if err != nil || *(result.(*rangeNexter)) != want {
 		t.Errorf("parseFieldNexterPart(%v, %v) = %v, %v WANT %v, %v", value, fi, result, err, want, nil)
 	}
 }
 
+func TestParseFieldNexterPart_singleValueStep(t *testing.T) {
+	tests := []struct {
+		fi     fieldIndex
+		value  string
+		result *rangeDivNexter
+	}{
+		{minute, "5/15", newRangeDivNexter(newRangeNexter(5, MaxMinute), 15)},
+		{hour, "0/6", newRangeDivNexter(newRangeNexter(0, MaxHour), 6)},
+		{dom, "2/2", newRangeDivNexter(newRangeNexter(2, MaxDom), 2)},
+		{month, "FEB/3", newRangeDivNexter(newRangeNexter(int(time.February), MaxMonth), 3)},
+		{dow, "MON/2", newRangeDivNexter(newRangeNexter(int(time.Monday), MaxDow), 2)},
+		{year, "2020/4", newRangeDivNexter(newRangeNexter(2020, MaxYear), 4)},
+	}
+	for _, test := range tests {
+		result, err := parseFieldNexterPart(test.value, test.fi, nil)
+		if err != nil || !reflect.DeepEqual(result, test.result) {
+			t.Errorf("parseFieldNexterPart(%v, %v) = %v, %v WANT %v, %v", test.value, test.fi, result, err, test.result, nil)
+		}
+	}
+}
+
 func TestParseFieldNexterPart_rangeDivNexter(t *testing.T) {
 	value := "40-50/2"
 	fi := minute
-	result, err := parseFieldNexterPart(value, fi)
+	result, err := parseFieldNexterPart(value, fi, nil)
 	want := newRangeDivNexter(newRangeNexter(40, 50), 2)
 	if err != nil || !reflect.DeepEqual(result, want) {
 		t.Errorf("parseFieldNexterPart(%v, %v) = %v, %v WANT %v, %v", value, fi, result, err, want, nil)
@@ -452,7 +467,7 @@ func TestParseRangeOrConstantNexter_range(t *testing.T) {
 		{second, "0-1", &rangeNexter{0, 1}, ""},
 	}
 	for _, test := range tests {
-		result, err := parseRangeOrConstantNexter(test.value, test.fi)
+		result, err := parseRangeOrConstantNexter(test.value, test.fi, nil)
 		if (err != nil || test.err != "") && err.Error() != test.err {
 			t.Errorf("parseRangeOrConstantNexter(%v, %v) error = %v WANT %v", test.value, test.fi, result, test.err)
 		}
@@ -479,7 +494,7 @@ func TestParseRangeOrConstantNexter_notRange(t *testing.T) {
 		{second, "57", 57, ""},
 	}
 	for _, test := range tests {
-		result, err := parseRangeOrConstantNexter(test.value, test.fi)
+		result, err := parseRangeOrConstantNexter(test.value, test.fi, nil)
 		if (err != nil || test.err != "") && err.Error() != test.err {
 			t.Errorf("parseRangeOrConstantNexter(%v, %v) error = %v WANT %v", test.value, test.fi, err, test.err)
 		}
@@ -507,13 +522,19 @@ func TestParseRangeNexter(t *testing.T) {
 		{second, "25-", invalidValue, invalidValue, "right side of range " + errParseInteger.Error()},
 		{month, "a-b", invalidValue, invalidValue, "left side of range " + errParseIntegerAlias.Error()},
 		{month, "-b", invalidValue, invalidValue, "left side of range " + errParseIntegerAlias.Error()},
-		{month, "4-2", invalidValue, invalidValue, "left side value of range must be strictly less than right side value"},
-		{dow, "SUN-SUN", invalidValue, invalidValue, "left side value of range must be strictly less than right side value"},
+		{month, "4-2", 4, 2, ""},
+		{dow, "SUN-SUN", invalidValue, invalidValue, "left side value of range must not equal right side value"},
+		{dow, "7-SUN", invalidValue, invalidValue, "left side value of range must not equal right side value"},
+		{dow, "0-7", int(time.Sunday), int(time.Saturday), ""},
+		{dow, "5-7", int(time.Friday), int(time.Sunday), ""},
+		{dow, "FRI-MON", int(time.Friday), int(time.Monday), ""},
+		{hour, "22-2", 22, 2, ""},
+		{year, "2027-2026", invalidValue, invalidValue, "left side value of range must be less than right side value in the year field"},
 		{month, "feb-may", int(time.February), int(time.May), ""},
 		{year, "1-3005", 1, 3005, ""},
 	}
 	for _, test := range tests {
-		result, err := parseRangeNexter(test.value, test.fi)
+		result, err := parseRangeNexter(test.value, test.fi, nil)
 		if err == nil && test.err != "" {
 			t.Fatalf("parseRangeNexter(%v, %v) WANT ERROR got nil", test.value, test.fi)
 		}
@@ -541,7 +562,7 @@ func TestConvertPossibleAnyToRange(t *testing.T) {
 		{year, Question, Question},
 	}
 	for _, test := range tests {
-		if result := convertPossibleAnyToRange(test.value, test.fi); result != test.result {
+		if result := convertPossibleAnyToRange(test.value, test.fi, nil); result != test.result {
 			t.Errorf("convertPossibleAnyToRange(%v, %v) = %v WANT %v", test.value, test.fi, result, test.result)
 		}
 	}
@@ -562,7 +583,7 @@ func TestParseValueNexter(t *testing.T) {
 		{year, "something", invalidValue, true},
 	}
 	for _, test := range tests {
-		result, err := parseValueNexter(test.value, test.fi)
+		result, err := parseValueNexter(test.value, test.fi, nil)
 		if (err != nil) != test.hasError {
 			t.Errorf("parseValueNexter(%v, %v) error = %v WANT ERROR %v", test.value, test.fi, err, test.hasError)
 		}
@@ -585,10 +606,15 @@ func TestParseSingleValue(t *testing.T) {
 		{month, "something", invalidValue, "must be a decimal integer or valid string alias"},
 		{dow, "tuesday", int(time.Tuesday), ""},
 		{dow, "SUN", int(time.Sunday), ""},
+		{dow, "7", int(time.Sunday), ""},
+		{dow, "8", invalidValue, errNotInRange.Error()},
+		{dow, "tues", invalidValue, errParseIntegerAlias.Error()},
+		{month, "JUNE", int(time.June), ""},
+		{month, "Janu", invalidValue, errParseIntegerAlias.Error()},
 		{year, "something", invalidValue, "must be a decimal integer"},
 	}
 	for _, test := range tests {
-		result, err := parseSingleValue(test.value, test.fi)
+		result, err := parseSingleValue(test.value, test.fi, nil)
 		if err != nil && err.Error() != test.err {
 			t.Errorf("parseSingleValue(%v, %v) error = %v WANT %v", test.value, test.fi, err, test.err)
 		}
@@ -684,3 +710,600 @@ func TestParseIncValue(t *testing.T) {
 		}
 	}
 }
+
+func TestParseWithSeed_splay(t *testing.T) {
+	tests := []struct {
+		expression string
+		window     time.Duration
+		err        string
+	}{
+		{Hourly + " " + Tilde + "10m", 10 * time.Minute, ""},
+		{Every + " 1h " + Tilde + "30s", 30 * time.Second, ""},
+		{"0 */5 * * * " + Tilde + "1m", time.Minute, ""},
+		{Hourly + " " + Tilde, 0, `sched: could not parse "@hourly ~": ~ splay window could not be parsed: time: invalid duration ""`},
+		{Hourly + " " + Tilde + "0s", 0, `sched: could not parse "@hourly ~0s": ~ splay window must be positive`},
+		{Tilde + "1m", 0, `sched: could not parse "~1m": number of fields must be 1, 2, 5, 6, or 7`},
+	}
+	for _, test := range tests {
+		result, err := ParseWithSeed(test.expression, "seed")
+		if (err != nil || test.err != "") && err.Error() != test.err {
+			t.Errorf("ParseWithSeed(%q) error = %v WANT %v", test.expression, err, test.err)
+		}
+		if err != nil {
+			continue
+		}
+		s, ok := result.(*SplaySchedule)
+		if !ok || s.Seed != "seed" || s.Window != test.window {
+			t.Errorf("ParseWithSeed(%q) = %v WANT splay window %v", test.expression, result, test.window)
+		}
+	}
+}
+
+func TestSplitSplayField(t *testing.T) {
+	tests := []struct {
+		expression string
+		rest       string
+		window     time.Duration
+		has        bool
+	}{
+		{"", "", 0, false},
+		{Hourly, Hourly, 0, false},
+		{Hourly + "  " + Tilde + "2m\n", Hourly, 2 * time.Minute, true},
+		{"0 0 * * *\t" + Tilde + "1h", "0 0 * * *", time.Hour, true},
+	}
+	for _, test := range tests {
+		rest, window, has, err := splitSplayField(test.expression)
+		if err != nil || rest != test.rest || window != test.window || has != test.has {
+			t.Errorf("splitSplayField(%q) = %q, %v, %v, %v WANT %q, %v, %v, nil",
+				test.expression, rest, window, has, err, test.rest, test.window, test.has,
+			)
+		}
+	}
+}
+
+func TestParseContext_hash(t *testing.T) {
+	a := (&parseContext{seed: "job a"}).hash(minute)
+	if again := (&parseContext{seed: "job a"}).hash(minute); again != a {
+		t.Errorf("hash() = %v WANT the same value %v", again, a)
+	}
+	if b := (&parseContext{seed: "job b"}).hash(minute); b == a {
+		t.Errorf("hash() for different seeds = %v, %v WANT different values", a, b)
+	}
+	if h := (&parseContext{seed: "job a"}).hash(hour); h == a {
+		t.Errorf("hash() for different fields = %v, %v WANT different values", a, h)
+	}
+	if h, want := (*parseContext)(nil).hash(minute), (&parseContext{}).hash(minute); h != want {
+		t.Errorf("nil.hash() = %v WANT %v", h, want)
+	}
+}
+
+func TestParseFieldNexterPart_hashed(t *testing.T) {
+	pc := &parseContext{seed: "job"}
+	tests := []struct {
+		value string
+		fi    fieldIndex
+		min   int
+		max   int
+		inc   int
+		err   string
+	}{
+		{Hashed, minute, MinMinute, MaxMinute, 0, ""},
+		{"h", hour, MinHour, MaxHour, 0, ""},
+		{Hashed, dom, MinDom, 28, 0, ""},
+		{Hashed, dow, MinDow, MaxDow, 0, ""},
+		{Hashed + "(0-29)", minute, 0, 29, 0, ""},
+		{Hashed + "(MON-FRI)", dow, int(time.Monday), int(time.Friday), 0, ""},
+		{Hashed + "(2000-2010)", year, 2000, 2010, 0, ""},
+		{Hashed + "/15", minute, MinMinute, MaxMinute, 15, ""},
+		{Hashed + "(10-40)/10", second, 10, 40, 10, ""},
+		{Hashed + "/60", minute, MinMinute, MaxMinute, 0, ""},
+		{Hashed, year, 0, 0, 0, `"H" requires an explicit range in the year field`},
+		{Hashed + "(0-29", minute, 0, 0, 0, `invalid "H" range "(0-29"`},
+		{Hashed + "(5)", minute, 0, 0, 0, `invalid "H" range "(5)"`},
+		{Hashed + "(0-60)", minute, 0, 0, 0, `"H" range right side of range not in range`},
+		{Hashed + "/0", minute, 0, 0, 0, `invalid "H" step value: step value must be a positive decimal integer`},
+	}
+	for _, test := range tests {
+		result, err := parseFieldNexterPart(test.value, test.fi, pc)
+		if (err != nil || test.err != "") && err.Error() != test.err {
+			t.Errorf("parseFieldNexterPart(%v, %v) error = %v WANT %v", test.value, test.fi, err, test.err)
+		}
+		if err != nil {
+			continue
+		}
+		if again, _ := parseFieldNexterPart(test.value, test.fi, pc); !reflect.DeepEqual(again, result) {
+			t.Errorf("parseFieldNexterPart(%v, %v) = %v WANT the same result %v", test.value, test.fi, again, result)
+		}
+		switch fn := result.(type) {
+		case valueNexter:
+			if test.inc != 0 || int(fn) < test.min || int(fn) > test.max {
+				t.Errorf("parseFieldNexterPart(%v, %v) = %v WANT value in %v-%v", test.value, test.fi, fn, test.min, test.max)
+			}
+		case *rangeDivNexter:
+			if fn.inc != test.inc || fn.min < test.min || fn.min >= test.min+test.inc || fn.max != test.max {
+				t.Errorf("parseFieldNexterPart(%v, %v) = %v WANT step %v from %v-%v", test.value, test.fi, fn, test.inc, test.min, test.max)
+			}
+		default:
+			t.Errorf("parseFieldNexterPart(%v, %v) = %v WANT valueNexter or *rangeDivNexter", test.value, test.fi, result)
+		}
+	}
+}
+
+func TestParseFieldNexterPart_random(t *testing.T) {
+	tests := []struct {
+		value string
+		fi    fieldIndex
+		min   int
+		max   int
+		inc   int
+		err   string
+	}{
+		{Random, second, MinSecond, MaxSecond, 0, ""},
+		{"r", month, MinMonth, MaxMonth, 0, ""},
+		{Random, dom, MinDom, 28, 0, ""},
+		{Random + "(9-17)", hour, 9, 17, 0, ""},
+		{Random + "/20", minute, MinMinute, MaxMinute, 20, ""},
+		{Random, year, 0, 0, 0, `"R" requires an explicit range in the year field`},
+		{Random + "9-17", hour, 0, 0, 0, `invalid "R" range "9-17"`},
+	}
+	for _, test := range tests {
+		pc := &parseContext{rand: rand.New(rand.NewSource(1))}
+		result, err := parseFieldNexterPart(test.value, test.fi, pc)
+		if (err != nil || test.err != "") && err.Error() != test.err {
+			t.Errorf("parseFieldNexterPart(%v, %v) error = %v WANT %v", test.value, test.fi, err, test.err)
+		}
+		if err != nil {
+			continue
+		}
+		pc = &parseContext{rand: rand.New(rand.NewSource(1))}
+		if again, _ := parseFieldNexterPart(test.value, test.fi, pc); !reflect.DeepEqual(again, result) {
+			t.Errorf("parseFieldNexterPart(%v, %v) = %v WANT the same result from the same source %v", test.value, test.fi, again, result)
+		}
+		switch fn := result.(type) {
+		case valueNexter:
+			if test.inc != 0 || int(fn) < test.min || int(fn) > test.max {
+				t.Errorf("parseFieldNexterPart(%v, %v) = %v WANT value in %v-%v", test.value, test.fi, fn, test.min, test.max)
+			}
+		case *rangeDivNexter:
+			if fn.inc != test.inc || fn.min < test.min || fn.min >= test.min+test.inc || fn.max != test.max {
+				t.Errorf("parseFieldNexterPart(%v, %v) = %v WANT step %v from %v-%v", test.value, test.fi, fn, test.inc, test.min, test.max)
+			}
+		default:
+			t.Errorf("parseFieldNexterPart(%v, %v) = %v WANT valueNexter or *rangeDivNexter", test.value, test.fi, result)
+		}
+	}
+}
+
+func TestParser_Parse_random(t *testing.T) {
+	const expression = "R R(9-17) * * MON-FRI"
+	a, err := (&Parser{Source: rand.NewSource(42)}).Parse(expression)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v WANT nil", expression, err)
+	}
+	b, _ := (&Parser{Source: rand.NewSource(42)}).Parse(expression)
+	if a.Expression() != b.Expression() {
+		t.Errorf("Parse(%q).Expression() = %v, %v WANT equal expressions from equal sources", expression, a.Expression(), b.Expression())
+	}
+	fields := Fields(a.Expression())
+	if len(fields) != int(fieldCount) || strings.ContainsAny(fields[minute]+fields[hour], Random) {
+		t.Fatalf("Parse(%q).Expression() = %v WANT resolved values", expression, a.Expression())
+	}
+	persisted := MustParse(a.Expression())
+	if persisted.Expression() != a.Expression() {
+		t.Errorf("MustParse(%q).Expression() = %v WANT a stable expression", a.Expression(), persisted.Expression())
+	}
+}
+
+func TestParseDateFieldNexterPart_dowRanges(t *testing.T) {
+	tests := []struct {
+		part   string
+		result string
+	}{
+		{"MON-FRI" + Hash + "1", "1-5#1"},
+		{"1-5/2" + Hash + "-1", "1-5/2#-1"},
+		{"FRI" + Last, "5L"},
+	}
+	for _, test := range tests {
+		result, err := parseDateFieldNexterPart(test.part, dow, nil)
+		if err != nil || result.String() != test.result {
+			t.Errorf("parseDateFieldNexterPart(%q, %v) = %v, %v WANT %v, nil", test.part, dow, result, err, test.result)
+		}
+	}
+}
+
+func TestParseSingleValue_lenient(t *testing.T) {
+	pc := &parseContext{lenient: true}
+	tests := []struct {
+		fi     fieldIndex
+		value  string
+		result int
+	}{
+		{month, "Janu", int(time.January)},
+		{month, "sept", int(time.September)},
+		{dow, "tues", int(time.Tuesday)},
+		{dow, "THURS", int(time.Thursday)},
+	}
+	for _, test := range tests {
+		result, err := parseSingleValue(test.value, test.fi, pc)
+		if result != test.result || err != nil {
+			t.Errorf("parseSingleValue(%v, %v) = %v, %v WANT %v, %v", test.value, test.fi, result, err, test.result, nil)
+		}
+	}
+}
+
+func TestParseSingleValue_sundayIsOne(t *testing.T) {
+	pc := &parseContext{dialect: &Quartz}
+	tests := []struct {
+		value  string
+		result int
+	}{
+		{"1", int(time.Sunday)},
+		{"7", int(time.Saturday)},
+		{"SUN", int(time.Sunday)},
+	}
+	for _, test := range tests {
+		result, err := parseSingleValue(test.value, dow, pc)
+		if result != test.result || err != nil {
+			t.Errorf("parseSingleValue(%v, %v) = %v, %v WANT %v, %v", test.value, dow, result, err, test.result, nil)
+		}
+	}
+}
+
+func TestParseRangeOrConstantNexter_wrapped(t *testing.T) {
+	tests := []struct {
+		fi     fieldIndex
+		value  string
+		result string
+	}{
+		{hour, "22-2", "22-2"},
+		{minute, "55-5", "55-5"},
+		{month, "NOV-FEB", "11-2"},
+		{dow, "FRI-MON", "5-1"},
+		{dow, "5-7", "5-0"},
+		{dow, "0-7", "0-6"},
+	}
+	for _, test := range tests {
+		result, err := parseRangeOrConstantNexter(test.value, test.fi, nil)
+		if err != nil || fmt.Sprint(result) != test.result {
+			t.Errorf("parseRangeOrConstantNexter(%v, %v) = %v, %v WANT %v, %v", test.value, test.fi, result, err, test.result, nil)
+		}
+	}
+}
+
+func TestParser_Parse_lenient(t *testing.T) {
+	if _, err := Parse("0 0 * Sept *"); err == nil {
+		t.Errorf("Parse(%q) error = nil WANT non-nil", "0 0 * Sept *")
+	}
+	p := &Parser{Lenient: true}
+	s, err := p.Parse("0 0 * Sept Thurs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expr := s.Expression(); expr != "0 0 0 * 9 4 *" {
+		t.Errorf("Parser.Parse().Expression() = %v WANT %v", expr, "0 0 0 * 9 4 *")
+	}
+}
+
+func TestParseFieldNexterPart_wrapRangeDivNexter(t *testing.T) {
+	tests := []struct {
+		fi     fieldIndex
+		value  string
+		result string
+	}{
+		{hour, "22-2/2", "22-2/2"},
+		{minute, "55-5/5", "55-5/5"},
+		{month, "NOV-FEB/2", "11-2/2"},
+	}
+	for _, test := range tests {
+		result, err := parseFieldNexterPart(test.value, test.fi, nil)
+		if err != nil || fmt.Sprint(result) != test.result {
+			t.Errorf("parseFieldNexterPart(%v, %v) = %v, %v WANT %v, %v", test.value, test.fi, result, err, test.result, nil)
+		}
+		if rdn, ok := result.(*rangeDivNexter); !ok || rdn.field != test.fi.fieldRange() {
+			t.Errorf("parseFieldNexterPart(%v, %v) = %#v WANT wrapped *rangeDivNexter", test.value, test.fi, result)
+		}
+	}
+}
+
+func TestParse_reboot(t *testing.T) {
+	for _, expression := range []string{Reboot, "@REBOOT", " @reboot\n"} {
+		s, err := Parse(expression)
+		if err != nil || s != NewRebootSchedule() {
+			t.Errorf("Parse(%q) = %v, %v WANT %v, %v", expression, s, err, NewRebootSchedule(), nil)
+		}
+	}
+	if _, err := (&Parser{Dialect: &Quartz}).Parse(Reboot); err == nil {
+		t.Errorf("Parser.Parse(%q) error = nil WANT non-nil", Reboot)
+	}
+}
+
+func TestParser_AddDirective(t *testing.T) {
+	p := &Parser{}
+	tests := []struct {
+		directive  string
+		expression string
+		err        bool
+	}{
+		{"@business-open", "0 9 * * MON-FRI", false},
+		{"@Business-Close", "0 17 * * MON-FRI", false},
+		{"@often", "@every 5m", false},
+		{"business", "0 9 * * *", true},
+		{"@business open", "0 9 * * *", true},
+		{"@daily", "0 9 * * *", true},
+		{"@Every", "0 9 * * *", true},
+		{Reboot, "0 9 * * *", true},
+		{"@bad", "0 9 * *", true},
+	}
+	for _, test := range tests {
+		err := p.AddDirective(test.directive, test.expression)
+		if (err != nil) != test.err {
+			t.Errorf("Parser.AddDirective(%q, %q) error = %v WANT error %v", test.directive, test.expression, err, test.err)
+		}
+	}
+	results := []struct {
+		expression string
+		result     string
+	}{
+		{"@business-open", "0 0 9 * * 1-5 *"},
+		{"@BUSINESS-OPEN", "0 0 9 * * 1-5 *"},
+		{"@business-close", "0 0 17 * * 1-5 *"},
+		{"@often", "@every 5m0s"},
+		{"@business-open ~10m", "0 0 9 * * 1-5 * ~10m0s"},
+	}
+	for _, test := range results {
+		s, err := p.Parse(test.expression)
+		if err != nil || s.Expression() != test.result {
+			t.Errorf("Parser.Parse(%q) = %v, %v WANT %v, %v", test.expression, s, err, test.result, nil)
+		}
+	}
+	if _, err := Parse("@business-open"); err == nil {
+		t.Errorf("Parse(%q) error = nil WANT non-nil", "@business-open")
+	}
+}
+
+func TestParseIntervalExpression(t *testing.T) {
+	tests := []struct {
+		directive string
+		values    []string
+		result    Schedule
+		err       string
+	}{
+		{Every, []string{"1h"}, NewIntervalSchedule(time.Hour), ""},
+		{"@EVERY", []string{"1d"}, NewIntervalSchedule(24 * time.Hour), ""},
+		{Every, []string{"2w"}, NewIntervalSchedule(14 * 24 * time.Hour), ""},
+		{Every, []string{"15m", From, "00:07"}, NewAnchoredIntervalSchedule(15*time.Minute, 7*time.Minute), ""},
+		{Every, []string{"1d", "FROM", "09:30:15"}, NewAnchoredIntervalSchedule(24*time.Hour, 9*time.Hour+30*time.Minute+15*time.Second), ""},
+		{Every, []string{"1h", Offset, "5m"}, NewAnchoredIntervalSchedule(time.Hour, 5*time.Minute), ""},
+		{"@sometimes", []string{"1h"}, nil, `the directive "@sometimes" is not recognized`},
+		{Every, []string{"1h", From}, nil, `@every must have a duration value optionally followed by "from" or "offset" and a value`},
+		{Every, []string{"0s"}, nil, "@every duration value must be positive"},
+		{Every, []string{"-1d"}, nil, "@every duration value must be positive"},
+		{Every, []string{"1x"}, nil, `@every duration value could not be parsed: time: unknown unit "x" in duration "1x"`},
+		{Every, []string{"1h", "at", "5m"}, nil, `@every anchor "at" must be "from" or "offset"`},
+		{Every, []string{"1h", From, "25:00"}, nil, `@every from value could not be parsed: time of day "25:00" must be in the form HH:MM or HH:MM:SS`},
+		{Every, []string{"1h", Offset, "five"}, nil, `@every offset value could not be parsed: invalid duration "five"`},
+	}
+	for _, test := range tests {
+		result, err := parseIntervalExpression(test.directive, test.values)
+		if (err != nil || test.err != "") && (err == nil || err.Error() != test.err) {
+			t.Errorf("parseIntervalExpression(%v, %v) error = %v WANT %v", test.directive, test.values, err, test.err)
+		}
+		if !reflect.DeepEqual(result, test.result) {
+			t.Errorf("parseIntervalExpression(%v, %v) result = %v WANT %v", test.directive, test.values, result, test.result)
+		}
+	}
+}
+
+func TestParseEveryDuration(t *testing.T) {
+	tests := []struct {
+		value  string
+		result time.Duration
+		err    bool
+	}{
+		{"1h30m", 90 * time.Minute, false},
+		{"1d", 24 * time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"1w2d12h", 9*24*time.Hour + 12*time.Hour, false},
+		{"-1d", -24 * time.Hour, false},
+		{"0", 0, false},
+		{"", 0, true},
+		{"-", 0, true},
+		{"d", 0, true},
+		{"1d5", 0, true},
+		{"1..5d", 0, true},
+		{"1y", 0, true},
+	}
+	for _, test := range tests {
+		result, err := parseEveryDuration(test.value)
+		if result != test.result || (err != nil) != test.err {
+			t.Errorf("parseEveryDuration(%q) = %v, %v WANT %v, error %v", test.value, result, err, test.result, test.err)
+		}
+	}
+}
+
+func TestParse_every(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     string
+	}{
+		{"@every 1d", "@every 24h0m0s"},
+		{"@every 15m from 00:07", "@every 15m0s offset 7m0s"},
+		{"@every 1h offset 65m ~30s", "@every 1h0m0s offset 5m0s ~30s"},
+	}
+	for _, test := range tests {
+		s, err := Parse(test.expression)
+		if err != nil || s.Expression() != test.result {
+			t.Errorf("Parse(%q) = %v, %v WANT %v, %v", test.expression, s, err, test.result, nil)
+		}
+	}
+	for _, expression := range []string{"@every 0s", "@every 1h from", "@every 1h offset 5m 1"} {
+		if _, err := Parse(expression); err == nil {
+			t.Errorf("Parse(%q) error = nil WANT non-nil", expression)
+		}
+	}
+	if _, err := (&Parser{Dialect: &Quartz}).Parse("@every 15m from 00:07"); err == nil {
+		t.Errorf("Parser.Parse(%q) error = nil WANT non-nil", "@every 15m from 00:07")
+	}
+}
+
+func TestSplitBoundsFields(t *testing.T) {
+	start, end := utc(2026, time.January, 1, 0, 0, 0), utc(2027, time.January, 1, 0, 0, 0)
+	tests := []struct {
+		expression string
+		rest       string
+		result     *BoundedSchedule
+		err        string
+	}{
+		{"", "", nil, ""},
+		{Hourly, Hourly, nil, ""},
+		{Hourly + " [2026-01-01T00:00:00Z,2027-01-01T00:00:00Z)", Hourly, &BoundedSchedule{Start: start, End: end}, ""},
+		{"0 0 * * * [,2027-01-01T00:00:00Z) x3", "0 0 * * *", &BoundedSchedule{End: end, MaxRuns: 3}, ""},
+		{Hourly + " x10", Hourly, &BoundedSchedule{MaxRuns: 10}, ""},
+		{Hourly + " x0", "", nil, "x maximum runs must be a positive decimal integer"},
+		{Hourly + " xa", "", nil, "x maximum runs must be a positive decimal integer"},
+		{Hourly + " x3 [2026-01-01T00:00:00Z,)", Hourly + " x3", &BoundedSchedule{Start: start}, ""},
+		{Hourly + " [2026-01-01T00:00:00Z)", "", nil, `window "[2026-01-01T00:00:00Z)" must be in the form [start,end)`},
+		{Hourly + " [2026-01-01T00:00:00Z,]", "", nil, `window "[2026-01-01T00:00:00Z,]" must be in the form [start,end)`},
+		{Hourly + " [2026-01-01,)", "", nil, `window start could not be parsed: parsing time "2026-01-01" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "T"`},
+		{Hourly + " [2027-01-01T00:00:00Z,2026-01-01T00:00:00Z)", "", nil, "window start must be before end"},
+	}
+	for _, test := range tests {
+		rest, result, err := splitBoundsFields(test.expression)
+		if (err != nil || test.err != "") && (err == nil || err.Error() != test.err) {
+			t.Errorf("splitBoundsFields(%q) error = %v WANT %v", test.expression, err, test.err)
+		}
+		if rest != test.rest || !reflect.DeepEqual(result, test.result) {
+			t.Errorf("splitBoundsFields(%q) = %q, %v WANT %q, %v", test.expression, rest, result, test.rest, test.result)
+		}
+	}
+}
+
+func TestParseAtExpression(t *testing.T) {
+	tests := []struct {
+		values []string
+		result Schedule
+		err    string
+	}{
+		{[]string{"2026-12-01T09:00:00Z"}, NewAtSchedule(utc(2026, time.December, 1, 9, 0, 0)), ""},
+		{[]string{}, nil, "@at must have a single time value"},
+		{[]string{"2026-12-01", "09:00"}, nil, "@at must have a single time value"},
+		{[]string{"tomorrow"}, nil, `@at time value could not be parsed: parsing time "tomorrow" as "2006-01-02T15:04:05Z07:00": cannot parse "tomorrow" as "2006"`},
+	}
+	for _, test := range tests {
+		result, err := parseAtExpression(test.values)
+		if (err != nil || test.err != "") && (err == nil || err.Error() != test.err) {
+			t.Errorf("parseAtExpression(%v) error = %v WANT %v", test.values, err, test.err)
+		}
+		if result != test.result && !time.Time(result.(AtSchedule)).Equal(time.Time(test.result.(AtSchedule))) {
+			t.Errorf("parseAtExpression(%v) result = %v WANT %v", test.values, result, test.result)
+		}
+	}
+}
+
+func TestParser_Parse_bounds(t *testing.T) {
+	p := &Parser{Now: func() time.Time {
+		return time.Date(2026, time.March, 1, 12, 30, 15, 500, time.UTC)
+	}}
+	tests := []struct {
+		expression string
+		result     string
+	}{
+		{"@at 2026-12-01T09:00:00Z", "@at 2026-12-01T09:00:00Z"},
+		{"@AT 2026-12-01T09:00:00-05:00", "@at 2026-12-01T09:00:00-05:00"},
+		{"@hourly x3", "0 0 * * * * * [2026-03-01T12:30:15Z,) x3"},
+		{"@every 1h [2026-01-01T00:00:00Z,2027-01-01T00:00:00Z) x2 ~5m", "@every 1h0m0s [2026-01-01T00:00:00Z,2027-01-01T00:00:00Z) x2 ~5m0s"},
+	}
+	for _, test := range tests {
+		s, err := p.Parse(test.expression)
+		if err != nil || s.Expression() != test.result {
+			t.Errorf("Parser.Parse(%q) = %v, %v WANT %v, %v", test.expression, s, err, test.result, nil)
+		}
+	}
+}
+
+func TestSplitComposite(t *testing.T) {
+	tests := []struct {
+		expression string
+		parts      []string
+		separator  string
+		err        string
+	}{
+		{Daily, []string{Daily}, "", ""},
+		{"0 9 * * *; 0 17 * * *", []string{"0 9 * * *", " 0 17 * * *"}, Semicolon, ""},
+		{"{a; b} EXCEPT c; d", []string{"{a; b} EXCEPT c", " d"}, Semicolon, ""},
+		{"a AND b EXCEPT c except d", []string{"a AND b", "c", "d"}, KeywordExcept, ""},
+		{"a and {b EXCEPT c}", []string{"a", "{b EXCEPT c}"}, KeywordAnd, ""},
+		{"{a; b}", []string{"{a; b}"}, "", ""},
+		{"a;", nil, Semicolon, `";" must be between two expressions`},
+		{"EXCEPT a", nil, KeywordExcept, `"EXCEPT" must be between two expressions`},
+		{"{a; b", nil, "", `"{" does not have a matching "}"`},
+		{"a} {b", nil, "", `"}" does not have a matching "{"`},
+	}
+	for _, test := range tests {
+		parts, separator, err := splitComposite(test.expression)
+		if (err != nil || test.err != "") && (err == nil || err.Error() != test.err) {
+			t.Errorf("splitComposite(%q) error = %v WANT %v", test.expression, err, test.err)
+		}
+		if err == nil && (!reflect.DeepEqual(parts, test.parts) || separator != test.separator) {
+			t.Errorf("splitComposite(%q) = %q, %q WANT %q, %q", test.expression, parts, separator, test.parts, test.separator)
+		}
+	}
+}
+
+func TestIsBraced(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     bool
+	}{
+		{"", false},
+		{"{}", true},
+		{"{a; b}", true},
+		{"{{a} EXCEPT b}", true},
+		{"{a} EXCEPT {b}", false},
+		{"{a", false},
+	}
+	for _, test := range tests {
+		if result := isBraced(test.expression); result != test.result {
+			t.Errorf("isBraced(%q) = %v WANT %v", test.expression, result, test.result)
+		}
+	}
+}
+
+func TestParse_composite(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     string
+		err        string
+	}{
+		{"0 9 * * MON-FRI; 0 17 * * MON-FRI EXCEPT 0 17 * * FRI", "{0 0 9 * * 1-5 *; {0 0 17 * * 1-5 * EXCEPT 0 0 17 * * 5 *}}", ""},
+		{"{@hourly; @every 1h ~1m} [2026-01-01T00:00:00Z,) x3", "{0 0 * * * * *; @every 1h0m0s ~1m0s} [2026-01-01T00:00:00Z,) x3", ""},
+		{"@hourly AND @reboot", "{0 0 * * * * * AND @reboot}", ""},
+		{"@hourly; 0 9 * *", "", "schedule 2: number of fields must be 1, 2, 5, 6, or 7"},
+		{"{@hourly; @daily", "", `"{" does not have a matching "}"`},
+	}
+	for _, test := range tests {
+		s, err := Parse(test.expression)
+		if (err != nil || test.err != "") && (err == nil || err.(*ParseError).Description != test.err) {
+			t.Errorf("Parse(%q) error = %v WANT %v", test.expression, err, test.err)
+		}
+		if err == nil && s.Expression() != test.result {
+			t.Errorf("Parse(%q).Expression() = %v WANT %v", test.expression, s.Expression(), test.result)
+		}
+	}
+
+	p := &Parser{}
+	if err := p.AddDirective("@business", "0 9 * * MON-FRI EXCEPT 0 9 25 12 *"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddDirective("@twice", "@business; 0 17 * * *"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Parse("@twice"); err == nil {
+		t.Errorf("Parser.Parse(%q) error = nil WANT non-nil", "@twice")
+	}
+	s, err := p.Parse("@business; @hourly")
+	want := "{{0 0 9 * * 1-5 * EXCEPT 0 0 9 25 12 * *}; 0 0 * * * * *}"
+	if err != nil || s.Expression() != want {
+		t.Errorf("Parser.Parse(%q) = %v, %v WANT %v", "@business; @hourly", s, err, want)
+	}
+}
@@ -2,6 +2,7 @@ package sched
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -56,7 +57,31 @@ const (
 	Secondly       = "@secondly"
 	SecondlyFormat = "* * * * * * *"
 
+	Midnight       = "@midnight"
+	MidnightFormat = DailyFormat
+
+	Quarterly       = "@quarterly"
+	QuarterlyFormat = "0 0 0 1 */3 * *"
+
+	Weekdays       = "@weekdays"
+	WeekdaysFormat = "0 0 0 * * 1-5 *"
+
+	Workdays       = "@workdays"
+	WorkdaysFormat = WeekdaysFormat
+
+	Weekends       = "@weekends"
+	WeekendsFormat = "0 0 0 * * 0,6 *"
+
 	Every = "@every"
+
+	//From and Offset anchor an Every directive, as in "@every 15m from 00:07" and
+	//"@every 1h offset 5m".
+	From   = "from"
+	Offset = "offset"
+
+	Reboot = "@reboot"
+
+	At = "@at"
 )
 
 const invalidValue = -1
@@ -85,6 +110,110 @@ func (s IntervalSchedule) Expression() string {
 	return fmt.Sprintf("%v %v", Every, time.Duration(s))
 }
 
+//AnchoredIntervalSchedule fires every Interval on a grid of wall clock times that is
+//Offset after midnight on January 1, 1970 in the location of the time it is given.
+//For example, an Interval of 15 minutes and Offset of 7 minutes fires at 7, 22, 37,
+//and 52 minutes past every hour.
+type AnchoredIntervalSchedule struct {
+	Interval time.Duration
+	Offset   time.Duration
+}
+
+//NewAnchoredIntervalSchedule returns an AnchoredIntervalSchedule with offset normalized
+//to be in [0, interval).
+func NewAnchoredIntervalSchedule(interval, offset time.Duration) Schedule {
+	if interval > 0 {
+		offset %= interval
+		if offset < 0 {
+			offset += interval
+		}
+	}
+	return &AnchoredIntervalSchedule{
+		Interval: interval,
+		Offset:   offset,
+	}
+}
+
+func (s *AnchoredIntervalSchedule) NextTime(from time.Time) (time.Time, bool) {
+	if s.Interval <= 0 {
+		return from, false
+	}
+	since := wallClock(from).Sub(wallEpoch) - s.Offset
+	steps := since / s.Interval
+	if since < 0 && since%s.Interval != 0 {
+		steps--
+	}
+	grid := wallEpoch.Add(s.Offset + (steps+1)*s.Interval)
+	for {
+		//repeated wall clock times, such as when daylight saving time ends, can map
+		//grid times to or before from.
+		result := time.Date(grid.Year(), grid.Month(), grid.Day(), grid.Hour(), grid.Minute(), grid.Second(), grid.Nanosecond(), from.Location())
+		if result.After(from) {
+			return result, true
+		}
+		grid = grid.Add(s.Interval)
+	}
+}
+
+func (s *AnchoredIntervalSchedule) String() string {
+	return fmt.Sprintf("sched.AnchoredIntervalSchedule(%v, %v)", s.Interval, s.Offset)
+}
+
+func (s *AnchoredIntervalSchedule) Expression() string {
+	return fmt.Sprintf("%v %v %v %v", Every, s.Interval, Offset, s.Offset)
+}
+
+var wallEpoch = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+//RebootSchedule never has a next time. A Cron releases jobs whose schedules are IsReboot
+//once when it is first started.
+type RebootSchedule struct{}
+
+func NewRebootSchedule() Schedule {
+	return RebootSchedule{}
+}
+
+func (s RebootSchedule) NextTime(from time.Time) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (s RebootSchedule) String() string {
+	return "sched.RebootSchedule()"
+}
+
+func (s RebootSchedule) Expression() string {
+	return Reboot
+}
+
+//IsReboot returns whether s fires at startup, which is when s is a RebootSchedule, a
+//UnionSchedule with one, an ExceptSchedule whose Base is one, or a schedule that wraps
+//one, such as a LocationSchedule.
+func IsReboot(s Schedule) bool {
+	switch s := s.(type) {
+	case RebootSchedule:
+		return true
+	case UnionSchedule:
+		for _, schedule := range s {
+			if IsReboot(schedule) {
+				return true
+			}
+		}
+	case *ExceptSchedule:
+		return IsReboot(s.Base)
+	case Expr:
+		return IsReboot(s.Schedule)
+	case *LocationSchedule:
+		return IsReboot(s.Schedule)
+	case *SplaySchedule:
+		return IsReboot(s.Schedule)
+	case *CalendarSchedule:
+		return IsReboot(s.Schedule)
+	case *BoundedSchedule:
+		return IsReboot(s.Schedule)
+	}
+	return false
+}
+
 type schedule struct {
 	second fieldNexter
 	minute fieldNexter
@@ -93,8 +222,15 @@ type schedule struct {
 	month  fieldNexter
 	dow    dateFieldNexter
 	year   fieldNexter
+
+	//daysOr is whether a day matches when either dom or dow matches it instead of both.
+	daysOr bool
 }
 
+//maxYearsSearched bounds the number of years NextTime looks through for a matching day.
+//The Gregorian calendar repeats every 400 years, so searching any more would not find one.
+const maxYearsSearched = 400
+
 func newSchedule() *schedule {
 	return &schedule{}
 }
@@ -103,18 +239,246 @@ func (s *schedule) setNexter(nexter interface{}, fi fieldIndex) {
 	if nexter == nil {
 		panic("nexter cannot be nil")
 	}
+	switch fi {
+	case second:
+		s.second = nexter.(fieldNexter)
+	case minute:
+		s.minute = nexter.(fieldNexter)
+	case hour:
+		s.hour = nexter.(fieldNexter)
+	case dom:
+		s.dom = nexter.(dateFieldNexter)
+	case month:
+		s.month = nexter.(fieldNexter)
+	case dow:
+		s.dow = nexter.(dateFieldNexter)
+	case year:
+		s.year = nexter.(fieldNexter)
+	default:
+		panic(fmt.Sprintf("invalid fieldIndex %v", int(fi)))
+	}
+}
+
+func (s *schedule) nexterString(fi fieldIndex) string {
+	var nexter fmt.Stringer
+	switch fi {
+	case second:
+		nexter = s.second
+	case minute:
+		nexter = s.minute
+	case hour:
+		nexter = s.hour
+	case dom:
+		nexter = s.dom
+	case month:
+		nexter = s.month
+	case dow:
+		nexter = s.dow
+	case year:
+		nexter = s.year
+	}
+	if nexter == nil {
+		return Asterisk
+	}
+	return fi.compactAny(nexter.String())
 }
 
 func (s *schedule) NextTime(from time.Time) (time.Time, bool) {
-	return from.Add(1), true
+	loc := from.Location()
+	t := from.Truncate(time.Second).Add(time.Second)
+	for yearsSearched := 0; yearsSearched <= maxYearsSearched; {
+		y, wrapped := ceil(s.year, t.Year())
+		if wrapped {
+			return time.Time{}, false
+		}
+		if y != t.Year() {
+			t = wallDate(y, time.January, 1, 0, loc)
+			yearsSearched++
+			continue
+		}
+		m, wrapped := ceil(s.month, int(t.Month()))
+		if wrapped {
+			t = wallDate(y+1, time.January, 1, 0, loc)
+			yearsSearched++
+			continue
+		}
+		if m != int(t.Month()) {
+			t = wallDate(y, time.Month(m), 1, 0, loc)
+			continue
+		}
+		d, wrapped := s.nextDay(t.Day()-1, t)
+		if wrapped {
+			t = wallDate(y, time.Month(m)+1, 1, 0, loc)
+			continue
+		}
+		if d != t.Day() {
+			t = wallDate(y, time.Month(m), d, 0, loc)
+			continue
+		}
+		h, wrapped := ceil(s.hour, t.Hour())
+		if wrapped {
+			t = wallDate(y, time.Month(m), d+1, 0, loc)
+			continue
+		}
+		if h != t.Hour() {
+			t = wallDate(y, time.Month(m), d, h, loc)
+			continue
+		}
+		//minutes and seconds are moved by adding durations so that times in a repeated
+		//hour at the end of daylight saving time do not move backwards.
+		mi, wrapped := ceil(s.minute, t.Minute())
+		if wrapped {
+			t = t.Add(time.Duration(MaxMinute+1-t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+			continue
+		}
+		if mi != t.Minute() {
+			t = t.Add(time.Duration(mi-t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+			continue
+		}
+		sec, wrapped := ceil(s.second, t.Second())
+		if wrapped {
+			t = t.Add(time.Duration(MaxSecond+1-t.Second()) * time.Second)
+			continue
+		}
+		if sec != t.Second() {
+			t = t.Add(time.Duration(sec-t.Second()) * time.Second)
+			continue
+		}
+		if !t.After(from) {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+//wallDate returns the start of the hour in loc like time.Date. However, an hour that is
+//skipped by a daylight saving time transition results in the first hour after it instead
+//of one before it.
+func wallDate(year int, month time.Month, day, hour int, loc *time.Location) time.Time {
+	result := time.Date(year, month, day, hour, 0, 0, 0, loc)
+	want := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	for wallClock(result).Before(want) {
+		result = result.Add(time.Hour)
+	}
+	return result
+}
+
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+//nextDay returns the next day in the month of t after now that matches both dom and dow,
+//or either of them if daysOr.
+//hasDays returns whether a day of s occurs in any month and year of s, which it does not
+//for expressions such as "0 0 0 30 2 *" that can never fire.
+func (s *schedule) hasDays() bool {
+	//the Gregorian calendar repeats every 400 years, so those years have every kind of month.
+	y, wrapped := ceil(s.year, MinYear)
+	for years := 0; !wrapped && years < maxYearsSearched; years++ {
+		for m := MinMonth; m <= MaxMonth; m++ {
+			if !contains(s.month, m) {
+				continue
+			}
+			if _, wrapped := s.nextDay(0, time.Date(y, time.Month(m), 1, 12, 0, 0, 0, time.UTC)); !wrapped {
+				return true
+			}
+		}
+		y, wrapped = s.year.next(y)
+	}
+	return false
+}
+
+func (s *schedule) nextDay(now int, t time.Time) (int, bool) {
+	if s.daysOr {
+		domDay, domWrapped := s.dom.next(now, t)
+		dowDay, dowWrapped := s.dow.next(now, t)
+		if domWrapped || (!dowWrapped && dowDay < domDay) {
+			return dowDay, dowWrapped
+		}
+		return domDay, false
+	}
+	for {
+		domDay, wrapped := s.dom.next(now, t)
+		if wrapped {
+			return invalidValue, true
+		}
+		dowDay, wrapped := s.dow.next(domDay-1, t)
+		if wrapped {
+			return invalidValue, true
+		}
+		if domDay == dowDay {
+			return domDay, false
+		}
+		now = dowDay - 1
+	}
+}
+
+//matches returns whether s fires at the second of t.
+func (s *schedule) matches(t time.Time) bool {
+	values := []struct {
+		fn    fieldNexter
+		value int
+	}{
+		{s.year, t.Year()}, {s.month, int(t.Month())}, {s.hour, t.Hour()}, {s.minute, t.Minute()}, {s.second, t.Second()},
+	}
+	for _, v := range values {
+		if next, wrapped := ceil(v.fn, v.value); wrapped || next != v.value {
+			return false
+		}
+	}
+	d, wrapped := s.nextDay(t.Day()-1, t)
+	return !wrapped && d == t.Day()
+}
+
+//nextUnmatched returns the first whole second at or after t that s does not fire at.
+//It moves by the smallest unit whose field is not every value, since s fires at every
+//second of a matching unit that is larger.
+func (s *schedule) nextUnmatched(t time.Time) (time.Time, bool) {
+	limit := t.AddDate(maxYearsSearched, 0, 0)
+	for t.Before(limit) {
+		if !s.matches(t) {
+			return t, true
+		}
+		switch {
+		case !isAny(s.second, second):
+			t = t.Add(time.Second)
+		case !isAny(s.minute, minute):
+			t = t.Add(time.Duration(MaxSecond+1-t.Second()) * time.Second)
+		case !isAny(s.hour, hour):
+			t = t.Add(time.Duration(MaxMinute+1-t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+		default:
+			t = wallDate(t.Year(), t.Month(), t.Day()+1, 0, t.Location())
+		}
+	}
+	return t, false
 }
 
 func (s *schedule) String() string {
 	return s.Expression()
 }
 
+//Expression returns the expression of s in the Standard dialect, which parses to a Schedule
+//with the same times.
 func (s *schedule) Expression() string {
-	return ""
+	fields := make([]string, 0, fieldCount)
+	for fi := second; fi < fieldCount; fi++ {
+		fields = append(fields, s.nexterString(fi))
+	}
+	domAny, dowAny := fields[dom] == Asterisk, fields[dow] == Asterisk
+	switch {
+	case s.daysOr && (domAny || dowAny):
+		//every day matches a full range, so either of them matches every day.
+		fields[dom], fields[dow] = Asterisk, Asterisk
+	case !s.daysOr && !domAny && !dowAny:
+		//Standard combines two restricted date fields with DaysOr, so both must match as
+		//an IntersectSchedule.
+		domFields := append([]string{}, fields...)
+		domFields[dow], fields[dom] = Asterisk, Asterisk
+		return OpenBrace + strings.Join(domFields, " ") + " " + KeywordAnd + " " + strings.Join(fields, " ") + CloseBrace
+	}
+	return strings.Join(fields, " ")
 }
 
 type fieldIndex int
@@ -132,6 +496,24 @@ const (
 
 var fieldNames = [...]string{"second", "minute", "hour", "day of month", "month", "day of week", "year"}
 
+//The indexes of the fields of an expression, as in ParseError.Field.
+const (
+	SecondField = int(second)
+	MinuteField = int(minute)
+	HourField   = int(hour)
+	DomField    = int(dom)
+	MonthField  = int(month)
+	DowField    = int(dow)
+	YearField   = int(year)
+	FieldCount  = int(fieldCount)
+)
+
+//FieldName returns the name of the field at index, such as "day of month", or "" if index
+//is not a field.
+func FieldName(index int) string {
+	return fieldIndex(index).String()
+}
+
 func (fi fieldIndex) String() string {
 	if fi >= 0 && fi < fieldCount {
 		return fieldNames[fi]
@@ -155,6 +537,18 @@ func (fi fieldIndex) rangeString() string {
 	return fmt.Sprintf("%v%v%v", fr.min, Hyphen, fr.max)
 }
 
+//compactAny replaces the full range of fi at the start of each part in field with Asterisk.
+func (fi fieldIndex) compactAny(field string) string {
+	rangeString := fi.rangeString()
+	parts := FieldParts(field)
+	for i, part := range parts {
+		if part == rangeString || strings.HasPrefix(part, rangeString+Slash) {
+			parts[i] = Asterisk + part[len(rangeString):]
+		}
+	}
+	return strings.Join(parts, Comma)
+}
+
 func (fi fieldIndex) fieldRange() *fieldRange {
 	if fi >= 0 && fi < fieldCount {
 		return fieldRanges[fi]
@@ -34,6 +34,72 @@ func TestIntervalSchedule_NextTime(t *testing.T) {
 	}
 }
 
+func TestNewAnchoredIntervalSchedule(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		offset   time.Duration
+		result   time.Duration
+	}{
+		{time.Hour, 5 * time.Minute, 5 * time.Minute},
+		{time.Hour, 65 * time.Minute, 5 * time.Minute},
+		{time.Hour, -5 * time.Minute, 55 * time.Minute},
+		{time.Hour, time.Hour, 0},
+	}
+	for _, test := range tests {
+		s := NewAnchoredIntervalSchedule(test.interval, test.offset).(*AnchoredIntervalSchedule)
+		if s.Interval != test.interval || s.Offset != test.result {
+			t.Errorf("NewAnchoredIntervalSchedule(%v, %v) = %v WANT offset %v", test.interval, test.offset, s, test.result)
+		}
+	}
+}
+
+func TestAnchoredIntervalSchedule_NextTime(t *testing.T) {
+	s := NewAnchoredIntervalSchedule(15*time.Minute, 7*time.Minute)
+	testNextTimes(t, s, utc(2016, time.March, 1, 9, 50, 0),
+		utc(2016, time.March, 1, 9, 52, 0), utc(2016, time.March, 1, 10, 7, 0), utc(2016, time.March, 1, 10, 22, 0),
+	)
+	testNextTimes(t, s, utc(2016, time.March, 1, 10, 7, 0), utc(2016, time.March, 1, 10, 22, 0))
+	testNextTimes(t, s, utc(1960, time.March, 1, 10, 8, 0), utc(1960, time.March, 1, 10, 22, 0))
+
+	s = NewAnchoredIntervalSchedule(24*time.Hour, 9*time.Hour+30*time.Minute)
+	testNextTimes(t, s, utc(2016, time.March, 1, 12, 0, 0),
+		utc(2016, time.March, 2, 9, 30, 0), utc(2016, time.March, 3, 9, 30, 0),
+	)
+
+	//January 1, 1970 was a Thursday.
+	s = NewAnchoredIntervalSchedule(7*24*time.Hour, 0)
+	testNextTimes(t, s, utc(2016, time.March, 1, 0, 0, 0),
+		utc(2016, time.March, 3, 0, 0, 0), utc(2016, time.March, 10, 0, 0, 0),
+	)
+
+	testNextTimes(t, &AnchoredIntervalSchedule{}, utc(2016, time.March, 1, 0, 0, 0), time.Time{})
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	s = NewAnchoredIntervalSchedule(time.Hour, 30*time.Minute)
+	testNextTimes(t, s, time.Date(2016, time.March, 13, 0, 45, 0, 0, loc),
+		time.Date(2016, time.March, 13, 1, 30, 0, 0, loc),
+		time.Date(2016, time.March, 13, 3, 30, 0, 0, loc),
+	)
+	testNextTimes(t, s, time.Date(2016, time.November, 6, 0, 45, 0, 0, loc),
+		time.Date(2016, time.November, 6, 1, 30, 0, 0, loc),
+		time.Date(2016, time.November, 6, 2, 30, 0, 0, loc),
+	)
+}
+
+func TestAnchoredIntervalSchedule_Expression(t *testing.T) {
+	s := NewAnchoredIntervalSchedule(15*time.Minute, 7*time.Minute)
+	want := "@every 15m0s offset 7m0s"
+	if result := s.Expression(); result != want {
+		t.Errorf("%v.Expression() = %v WANT %v", s, result, want)
+	}
+	if again := MustParse(want).Expression(); again != want {
+		t.Errorf("MustParse(%q).Expression() = %v WANT %v", want, again, want)
+	}
+}
+
 func TestIntervalSchedule_String(t *testing.T) {
 	s := NewIntervalSchedule(time.Hour)
 	want := "sched.IntervalSchedule(" + time.Hour.String() + ")"
@@ -72,6 +138,25 @@ func TestFieldIndex_String(t *testing.T) {
 	}
 }
 
+func TestFieldName(t *testing.T) {
+	tests := []struct {
+		index  int
+		result string
+	}{
+		{-1, ""},
+		{SecondField, "second"},
+		{DomField, "day of month"},
+		{DowField, "day of week"},
+		{YearField, "year"},
+		{FieldCount, ""},
+	}
+	for _, test := range tests {
+		if result := FieldName(test.index); result != test.result {
+			t.Errorf("FieldName(%v) = %v WANT %v", test.index, result, test.result)
+		}
+	}
+}
+
 func TestFieldIndex_isInRange(t *testing.T) {
 	tests := []struct {
 		fi     fieldIndex
@@ -262,3 +347,338 @@ func TestFieldRange_isInRange(t *testing.T) {
 		}
 	}
 }
+
+func TestSchedule_Expression(t *testing.T) {
+	tests := []struct {
+		expression string
+		result     string
+	}{
+		{Secondly, "* * * * * * *"},
+		{Daily, "0 0 0 * * * *"},
+		{Weekly, "0 0 0 * * 0 *"},
+		{Midnight, "0 0 0 * * * *"},
+		{Quarterly, "0 0 0 1 */3 * *"},
+		{Weekdays, "0 0 0 * * 1-5 *"},
+		{Weekends, "0 0 0 * * 0,6 *"},
+		{Reboot, Reboot},
+		{"*/15 9-17 * JAN,jul MON-FRI", "0 */15 9-17 * 1,7 1-5 *"},
+		{"0 0 12 L * ? 2020-2030/2", "0 0 12 L * * 2020-2030/2"},
+		{"0 0 12 15W,LW * 5L,3#2", "0 0 12 15W,LW * 5L,3#2 *"},
+		{"0 0 12 L-3,L-2W * ?", "0 0 12 L-3,L-2W * * *"},
+		{"0 0 12 ? * MON-FRI#1,FRI#-2,SUN-SAT#5,4L", "0 0 12 * * 1-5#1,5#-2,0-6#5,4L *"},
+		{"0 0-59/2 0-23 1-31 1-12 0-6 *", "0 */2 * * * * *"},
+		{"0 22-2 * NOV-FEB FRI-MON", "0 0 22-2 * 11-2 5-1 *"},
+		{"0 0 * * 0-7", "0 0 0 * * * *"},
+		{"0 0 * * 5-7,7", "0 0 0 * * 5-0,0 *"},
+		{"5/15 0/6 * * *", "0 5-59/15 */6 * * * *"},
+		{"55-5/5 22-2/2 28-3 NOV-FEB/2 *", "0 55-5/5 22-2/2 28-3 11-2/2 * *"},
+		{"0 59/30 * * * *", "0 59/30 * * * * *"},
+		{"0 0 23/5 * * *", "0 0 23/5 * * * *"},
+		{"0 0 0 31/5 * *", "0 0 0 31/5 * * *"},
+		{"0 0 0 ? * SAT/7", "0 0 0 * * 6/7 *"},
+		{"0 0 0 1 DEC/2 ?", "0 0 0 1 12/2 * *"},
+	}
+	for _, test := range tests {
+		result := MustParse(test.expression).Expression()
+		if result != test.result {
+			t.Errorf("MustParse(%q).Expression() = %v WANT %v", test.expression, result, test.result)
+		}
+		if again := MustParse(result).Expression(); again != result {
+			t.Errorf("MustParse(%q).Expression() = %v WANT %v", result, again, result)
+		}
+	}
+}
+
+func TestFieldIndex_compactAny(t *testing.T) {
+	tests := []struct {
+		fi     fieldIndex
+		field  string
+		result string
+	}{
+		{second, "0-59", Asterisk},
+		{second, "0-59/5", Asterisk + "/5"},
+		{second, "0-58", "0-58"},
+		{hour, "1,0-23/2,5", "1,*/2,5"},
+		{dom, "1-31L", "1-31L"},
+	}
+	for _, test := range tests {
+		if result := test.fi.compactAny(test.field); result != test.result {
+			t.Errorf("%v.compactAny(%v) = %v WANT %v", test.fi, test.field, result, test.result)
+		}
+	}
+}
+
+func utc(year int, month time.Month, day, hour, minute, second int) time.Time {
+	return time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+}
+
+//testNextTimes checks that s fires at each of want in order starting from from.
+//A zero time in want means that s has no next time.
+func testNextTimes(t *testing.T, s Schedule, from time.Time, want ...time.Time) {
+	for _, w := range want {
+		next, ok := s.NextTime(from)
+		if w.IsZero() {
+			if ok {
+				t.Errorf("%v.NextTime(%v) = %v, %v WANT false", s.Expression(), from, next, ok)
+			}
+			return
+		}
+		if !ok || !next.Equal(w) {
+			t.Errorf("%v.NextTime(%v) = %v, %v WANT %v, true", s.Expression(), from, next, ok, w)
+			return
+		}
+		from = next
+	}
+}
+
+func TestRebootSchedule_NextTime(t *testing.T) {
+	testNextTimes(t, NewRebootSchedule(), utc(2016, time.January, 1, 0, 0, 0), time.Time{})
+}
+
+func TestIsReboot(t *testing.T) {
+	reboot, daily := NewRebootSchedule(), NewIntervalSchedule(24*time.Hour)
+	tests := []struct {
+		s      Schedule
+		result bool
+	}{
+		{reboot, true},
+		{daily, false},
+		{Union(daily, reboot), true},
+		{Union(daily, daily), false},
+		{Except(reboot, daily), true},
+		{Except(daily, reboot), false},
+		{Expr{Schedule: reboot}, true},
+		{NewLocationSchedule(reboot, time.UTC), true},
+		{NewSplaySchedule(Union(reboot, daily), "seed", time.Minute), true},
+		{NewCalendarSchedule(reboot, NewWeeklyCalendar(time.Sunday)), true},
+		{NewWindowSchedule(daily, time.Time{}, time.Time{}), false},
+	}
+	for _, test := range tests {
+		if result := IsReboot(test.s); result != test.result {
+			t.Errorf("IsReboot(%v) = %v WANT %v", test.s, result, test.result)
+		}
+	}
+	for _, expression := range []string{"@reboot ; @daily", "CRON_TZ=UTC @reboot", "@reboot ~5m"} {
+		s, err := Parse(expression)
+		if err != nil || !IsReboot(s) {
+			t.Errorf("IsReboot(Parse(%q)) = false, %v WANT true", expression, err)
+		}
+	}
+}
+
+func TestSchedule_NextTime(t *testing.T) {
+	tests := []struct {
+		expression string
+		from       time.Time
+		want       []time.Time
+	}{
+		{Secondly, utc(2016, time.March, 1, 23, 59, 58), []time.Time{
+			utc(2016, time.March, 1, 23, 59, 59), utc(2016, time.March, 2, 0, 0, 0), utc(2016, time.March, 2, 0, 0, 1),
+		}},
+		{"*/15 * * * * *", utc(2016, time.March, 1, 10, 0, 7), []time.Time{
+			utc(2016, time.March, 1, 10, 0, 15), utc(2016, time.March, 1, 10, 0, 30), utc(2016, time.March, 1, 10, 0, 45), utc(2016, time.March, 1, 10, 1, 0),
+		}},
+		{"30 9 * JAN,JUL *", utc(2016, time.August, 1, 0, 0, 0), []time.Time{
+			utc(2017, time.January, 1, 9, 30, 0), utc(2017, time.January, 2, 9, 30, 0),
+		}},
+		{"0 0 0 31 * * *", utc(2016, time.January, 31, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 31, 0, 0, 0), utc(2016, time.May, 31, 0, 0, 0), utc(2016, time.July, 31, 0, 0, 0),
+		}},
+		{"0 0 29 2 *", utc(2017, time.January, 1, 0, 0, 0), []time.Time{
+			utc(2020, time.February, 29, 0, 0, 0), utc(2024, time.February, 29, 0, 0, 0),
+		}},
+		{"0 0 0 1 1 * 2020-2021", utc(2019, time.June, 1, 0, 0, 0), []time.Time{
+			utc(2020, time.January, 1, 0, 0, 0), utc(2021, time.January, 1, 0, 0, 0), {},
+		}},
+		{"0 12 * * 5", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 4, 12, 0, 0), utc(2016, time.March, 11, 12, 0, 0), utc(2016, time.March, 18, 12, 0, 0),
+		}},
+		{"0 0 1,15 * MON", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 7, 0, 0, 0), utc(2016, time.March, 14, 0, 0, 0), utc(2016, time.March, 15, 0, 0, 0),
+			utc(2016, time.March, 21, 0, 0, 0), utc(2016, time.March, 28, 0, 0, 0), utc(2016, time.April, 1, 0, 0, 0),
+		}},
+		{"0 0 ? * MON", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 7, 0, 0, 0), utc(2016, time.March, 14, 0, 0, 0),
+		}},
+		{"0 0 L * ?", utc(2016, time.January, 31, 0, 0, 0), []time.Time{
+			utc(2016, time.February, 29, 0, 0, 0), utc(2016, time.March, 31, 0, 0, 0), utc(2016, time.April, 30, 0, 0, 0),
+		}},
+		{"0 0 L-3 * ?", utc(2016, time.January, 31, 0, 0, 0), []time.Time{
+			utc(2016, time.February, 26, 0, 0, 0), utc(2016, time.March, 28, 0, 0, 0), utc(2016, time.April, 27, 0, 0, 0),
+		}},
+		{"0 0 LW * ?", utc(2016, time.June, 30, 0, 0, 0), []time.Time{
+			utc(2016, time.July, 29, 0, 0, 0), utc(2016, time.August, 31, 0, 0, 0), utc(2016, time.September, 30, 0, 0, 0),
+		}},
+		{"0 9 1W * ?", utc(2016, time.September, 1, 9, 0, 0), []time.Time{
+			utc(2016, time.October, 3, 9, 0, 0), utc(2016, time.November, 1, 9, 0, 0),
+		}},
+		{"0 9 15W,L * ?", utc(2016, time.October, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.October, 14, 9, 0, 0), utc(2016, time.October, 31, 9, 0, 0), utc(2016, time.November, 15, 9, 0, 0),
+		}},
+		{"0 0 ? * 5#3", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 18, 0, 0, 0), utc(2016, time.April, 15, 0, 0, 0), utc(2016, time.May, 20, 0, 0, 0),
+		}},
+		{"0 0 ? * 6L", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 26, 0, 0, 0), utc(2016, time.April, 30, 0, 0, 0), utc(2016, time.May, 28, 0, 0, 0),
+		}},
+		{"0 0 ? * MON#5", utc(2016, time.January, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.February, 29, 0, 0, 0), utc(2016, time.May, 30, 0, 0, 0), utc(2016, time.August, 29, 0, 0, 0),
+			utc(2016, time.October, 31, 0, 0, 0),
+		}},
+		{"0 0 ? * FRI#-2", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 18, 0, 0, 0), utc(2016, time.April, 22, 0, 0, 0),
+		}},
+		{"0 22-2 * * *", utc(2016, time.March, 1, 21, 0, 0), []time.Time{
+			utc(2016, time.March, 1, 22, 0, 0), utc(2016, time.March, 1, 23, 0, 0), utc(2016, time.March, 2, 0, 0, 0),
+			utc(2016, time.March, 2, 1, 0, 0), utc(2016, time.March, 2, 2, 0, 0), utc(2016, time.March, 2, 22, 0, 0),
+		}},
+		{"5/20 3/10 * * *", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 1, 3, 5, 0), utc(2016, time.March, 1, 3, 25, 0), utc(2016, time.March, 1, 3, 45, 0),
+			utc(2016, time.March, 1, 13, 5, 0), utc(2016, time.March, 1, 13, 25, 0), utc(2016, time.March, 1, 13, 45, 0),
+			utc(2016, time.March, 1, 23, 5, 0), utc(2016, time.March, 1, 23, 25, 0), utc(2016, time.March, 1, 23, 45, 0),
+			utc(2016, time.March, 2, 3, 5, 0),
+		}},
+		{Quarterly, utc(2016, time.January, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.April, 1, 0, 0, 0), utc(2016, time.July, 1, 0, 0, 0), utc(2016, time.October, 1, 0, 0, 0),
+			utc(2017, time.January, 1, 0, 0, 0),
+		}},
+		{Weekends, utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 5, 0, 0, 0), utc(2016, time.March, 6, 0, 0, 0), utc(2016, time.March, 12, 0, 0, 0),
+		}},
+		{"0 0 */10 * *", utc(2016, time.February, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.February, 11, 0, 0, 0), utc(2016, time.February, 21, 0, 0, 0), utc(2016, time.March, 1, 0, 0, 0),
+			utc(2016, time.March, 11, 0, 0, 0), utc(2016, time.March, 21, 0, 0, 0), utc(2016, time.March, 31, 0, 0, 0),
+		}},
+		{"0 22-2/2 * * *", utc(2016, time.March, 1, 21, 0, 0), []time.Time{
+			utc(2016, time.March, 1, 22, 0, 0), utc(2016, time.March, 2, 0, 0, 0), utc(2016, time.March, 2, 2, 0, 0),
+			utc(2016, time.March, 2, 22, 0, 0),
+		}},
+		{"55-5 * * * *", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 1, 0, 1, 0), utc(2016, time.March, 1, 0, 2, 0), utc(2016, time.March, 1, 0, 3, 0),
+			utc(2016, time.March, 1, 0, 4, 0), utc(2016, time.March, 1, 0, 5, 0), utc(2016, time.March, 1, 0, 55, 0),
+		}},
+		{"0 0 30-2 * *", utc(2016, time.February, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.February, 2, 0, 0, 0), utc(2016, time.March, 1, 0, 0, 0), utc(2016, time.March, 2, 0, 0, 0),
+			utc(2016, time.March, 30, 0, 0, 0), utc(2016, time.March, 31, 0, 0, 0), utc(2016, time.April, 1, 0, 0, 0),
+		}},
+		{"0 0 * NOV-FEB 7", utc(2016, time.February, 20, 0, 0, 0), []time.Time{
+			utc(2016, time.February, 21, 0, 0, 0), utc(2016, time.February, 28, 0, 0, 0), utc(2016, time.November, 6, 0, 0, 0),
+		}},
+		{"0 0 * * FRI-MON", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 4, 0, 0, 0), utc(2016, time.March, 5, 0, 0, 0), utc(2016, time.March, 6, 0, 0, 0),
+			utc(2016, time.March, 7, 0, 0, 0), utc(2016, time.March, 11, 0, 0, 0),
+		}},
+		{"0 0 ? * MON-FRI#1", utc(2016, time.February, 29, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 1, 0, 0, 0), utc(2016, time.March, 2, 0, 0, 0), utc(2016, time.March, 3, 0, 0, 0),
+			utc(2016, time.March, 4, 0, 0, 0), utc(2016, time.March, 7, 0, 0, 0), utc(2016, time.April, 1, 0, 0, 0),
+		}},
+	}
+	for _, test := range tests {
+		testNextTimes(t, MustParse(test.expression), test.from, test.want...)
+	}
+}
+
+func TestSchedule_NextTime_dayCombinations(t *testing.T) {
+	tests := []struct {
+		d          Dialect
+		expression string
+		from       time.Time
+		want       []time.Time
+	}{
+		{Dialect{Seconds: true, Days: DaysAnd}, "0 0 0 13 * FRI", utc(2016, time.January, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.May, 13, 0, 0, 0), utc(2017, time.January, 13, 0, 0, 0), utc(2017, time.October, 13, 0, 0, 0),
+			utc(2018, time.April, 13, 0, 0, 0), utc(2018, time.July, 13, 0, 0, 0),
+		}},
+		{Dialect{Seconds: true, Days: DaysAnd}, "0 0 0 29 2 1", utc(2016, time.January, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.February, 29, 0, 0, 0), utc(2044, time.February, 29, 0, 0, 0),
+		}},
+		{Dialect{Seconds: true}, "0 0 0 13 * FRI", utc(2016, time.May, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.May, 6, 0, 0, 0), utc(2016, time.May, 13, 0, 0, 0), utc(2016, time.May, 20, 0, 0, 0),
+		}},
+		{Quartz, "0 0 12 ? * 6", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 4, 12, 0, 0), utc(2016, time.March, 11, 12, 0, 0),
+		}},
+		{Quartz, "0 0 12 13 * ?", utc(2016, time.March, 1, 0, 0, 0), []time.Time{
+			utc(2016, time.March, 13, 12, 0, 0), utc(2016, time.April, 13, 12, 0, 0),
+		}},
+	}
+	for _, test := range tests {
+		d := test.d
+		s, err := (&Parser{Dialect: &d}).Parse(test.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v WANT nil", test.expression, err)
+			continue
+		}
+		testNextTimes(t, s, test.from, test.want...)
+	}
+}
+
+func TestSchedule_Expression_dayCombinations(t *testing.T) {
+	daysAnd := Dialect{Seconds: true, Year: true, OptionalYear: true, Days: DaysAnd}
+	tests := []struct {
+		d          Dialect
+		expression string
+		result     string
+	}{
+		{Standard, "0 0 0 1-31 * 1", "0 0 0 * * * *"},
+		{Standard, "0 0 0 13 * 0-6", "0 0 0 * * * *"},
+		{Standard, "0 0 0 13 * 5", "0 0 0 13 * 5 *"},
+		{Standard, "0 0 0 L * 1#2", "0 0 0 L * 1#2 *"},
+		{daysAnd, "0 0 0 1-7 * 1", "{0 0 0 1-7 * * * AND 0 0 0 * * 1 *}"},
+		{daysAnd, "0 0 0 1-31 * 1", "0 0 0 * * 1 *"},
+		{daysAnd, "0 0 0 13 * 5 2020-2030", "{0 0 0 13 * * 2020-2030 AND 0 0 0 * * 5 2020-2030}"},
+		{daysAnd, "0 0 0 1-7 * 1 ~1h", "{0 0 0 1-7 * * * AND 0 0 0 * * 1 *} ~1h0m0s"},
+		{Quartz, "0 0 12 13 * ?", "0 0 12 13 * * *"},
+		{Quartz, "0 0 12 ? * 2-6", "0 0 12 * * 1-5 *"},
+	}
+	from := utc(2016, time.January, 1, 0, 0, 0)
+	for _, test := range tests {
+		d := test.d
+		s, err := (&Parser{Dialect: &d}).Parse(test.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v WANT nil", test.expression, err)
+			continue
+		}
+		result := s.Expression()
+		if result != test.result {
+			t.Errorf("Parse(%q).Expression() = %v WANT %v", test.expression, result, test.result)
+		}
+		again, err := Parse(result)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v WANT nil", result, err)
+			continue
+		}
+		next, nextAgain := from, from
+		for i := 0; i < 20; i++ {
+			next, _ = s.NextTime(next)
+			nextAgain, _ = again.NextTime(nextAgain)
+			if !next.Equal(nextAgain) {
+				t.Errorf("Parse(%q).NextTime() = %v WANT %v like %q", result, nextAgain, next, test.expression)
+				break
+			}
+		}
+	}
+}
+
+func TestSchedule_NextTime_location(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	s := MustParse("30 2 * * *")
+	//2:30 does not exist on 2016-03-13, so it is skipped.
+	testNextTimes(t, s, time.Date(2016, time.March, 12, 12, 0, 0, 0, loc),
+		time.Date(2016, time.March, 14, 2, 30, 0, 0, loc),
+		time.Date(2016, time.March, 15, 2, 30, 0, 0, loc),
+	)
+	s = MustParse("0 * * * *")
+	testNextTimes(t, s, time.Date(2016, time.March, 13, 0, 30, 0, 0, loc),
+		time.Date(2016, time.March, 13, 1, 0, 0, 0, loc),
+		time.Date(2016, time.March, 13, 3, 0, 0, 0, loc),
+		time.Date(2016, time.March, 13, 4, 0, 0, 0, loc),
+	)
+	s = MustParse("0 1 * * *")
+	testNextTimes(t, s, time.Date(2016, time.November, 5, 12, 0, 0, 0, loc),
+		time.Date(2016, time.November, 6, 1, 0, 0, 0, loc),
+	)
+}
@@ -0,0 +1,59 @@
+package sched
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+//SplaySchedule offsets every fire time of its Schedule by a fixed amount within
+//Window. The offset is derived from a hash of Seed, so schedules with different
+//seeds are spread out across the window while each one stays reproducible.
+type SplaySchedule struct {
+	Schedule
+	Seed   string
+	Window time.Duration
+}
+
+func NewSplaySchedule(s Schedule, seed string, window time.Duration) *SplaySchedule {
+	return &SplaySchedule{
+		Schedule: s,
+		Seed:     seed,
+		Window:   window,
+	}
+}
+
+//Offset returns the offset of the fire times of s, which is a whole number of seconds less
+//than Window.
+func (s *SplaySchedule) Offset() time.Duration {
+	seconds := uint64(s.Window / time.Second)
+	if seconds == 0 {
+		return 0
+	}
+	return time.Duration(hashSeed(s.Seed)%seconds) * time.Second
+}
+
+func (s *SplaySchedule) NextTime(from time.Time) (time.Time, bool) {
+	offset := s.Offset()
+	next, ok := s.Schedule.NextTime(from.Add(-offset))
+	if !ok {
+		return time.Time{}, false
+	}
+	return next.Add(offset), true
+}
+
+func (s *SplaySchedule) String() string {
+	return fmt.Sprintf("sched.SplaySchedule(%v, %q, %v)", s.Schedule, s.Seed, s.Window)
+}
+
+//Expression returns the expression of s, which does not include Seed. Parsing it gives the
+//same offset only with the same seed, as with ParseWithSeed or Parser.Seed.
+func (s *SplaySchedule) Expression() string {
+	return fmt.Sprintf("%v %v%v", s.Schedule.Expression(), Tilde, s.Window)
+}
+
+func hashSeed(seed string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	return h.Sum64()
+}
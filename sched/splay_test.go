@@ -0,0 +1,91 @@
+package sched
+
+import (
+	"testing"
+	"time"
+)
+
+//truncateSchedule fires at every multiple of its duration since the zero time.
+type truncateSchedule time.Duration
+
+func (s truncateSchedule) NextTime(from time.Time) (time.Time, bool) {
+	return from.Truncate(time.Duration(s)).Add(time.Duration(s)), true
+}
+
+func (s truncateSchedule) Expression() string {
+	return Hourly
+}
+
+func TestNewSplaySchedule(t *testing.T) {
+	s := NewSplaySchedule(truncateSchedule(time.Hour), "seed", time.Minute)
+	if s.Schedule != truncateSchedule(time.Hour) || s.Seed != "seed" || s.Window != time.Minute {
+		t.Errorf("NewSplaySchedule() = %v", s)
+	}
+}
+
+func TestSplaySchedule_Offset(t *testing.T) {
+	tests := []struct {
+		seed   string
+		window time.Duration
+	}{
+		{"", time.Minute},
+		{"job a", time.Minute},
+		{"job b", time.Hour},
+		{"job c", time.Nanosecond},
+	}
+	for _, test := range tests {
+		s := NewSplaySchedule(truncateSchedule(time.Hour), test.seed, test.window)
+		offset := s.Offset()
+		if offset < 0 || (offset >= test.window && offset > 0) || offset%time.Second != 0 {
+			t.Errorf("%v.Offset() = %v WANT whole seconds in [0, %v)", s, offset, test.window)
+		}
+		if again := NewSplaySchedule(truncateSchedule(time.Hour), test.seed, test.window).Offset(); again != offset {
+			t.Errorf("%v.Offset() = %v WANT the same offset %v", s, again, offset)
+		}
+	}
+	if offset := NewSplaySchedule(truncateSchedule(time.Hour), "seed", 0).Offset(); offset != 0 {
+		t.Errorf("Offset() with zero window = %v WANT 0", offset)
+	}
+	a := NewSplaySchedule(truncateSchedule(time.Hour), "job a", time.Hour).Offset()
+	b := NewSplaySchedule(truncateSchedule(time.Hour), "job b", time.Hour).Offset()
+	if a == b {
+		t.Errorf("Offset() for different seeds = %v, %v WANT different offsets", a, b)
+	}
+}
+
+func TestSplaySchedule_NextTime(t *testing.T) {
+	s := NewSplaySchedule(truncateSchedule(time.Hour), "job", time.Hour)
+	offset := s.Offset()
+	hour := time.Date(2016, time.March, 4, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		from   time.Time
+		result time.Time
+	}{
+		{hour, hour.Add(offset)},
+		{hour.Add(offset).Add(-1), hour.Add(offset)},
+		{hour.Add(offset), hour.Add(time.Hour + offset)},
+		{hour.Add(time.Hour - 1), hour.Add(time.Hour + offset)},
+	}
+	for _, test := range tests {
+		result, ok := s.NextTime(test.from)
+		if !result.Equal(test.result) || !ok {
+			t.Errorf("%v.NextTime(%v) = %v, %v WANT %v, %v", s, test.from, result, ok, test.result, true)
+		}
+	}
+}
+
+func TestSplaySchedule_NextTime_none(t *testing.T) {
+	s := NewSplaySchedule(MustParse("0 0 0 1 1 * 2020"), "job", time.Hour)
+	from := time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC)
+	if result, ok := s.NextTime(from); !result.IsZero() || ok {
+		t.Errorf("%v.NextTime(%v) = %v, %v WANT the zero time, false", s, from, result, ok)
+	}
+}
+
+func TestSplaySchedule_Expression(t *testing.T) {
+	s := NewSplaySchedule(truncateSchedule(time.Hour), "job", 5*time.Minute)
+	want := Hourly + " " + Tilde + "5m0s"
+	if result := s.Expression(); result != want {
+		t.Errorf("%v.Expression() = %v WANT %v", s, result, want)
+	}
+}
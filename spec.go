@@ -0,0 +1,280 @@
+package cron
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogolfing/cron/sched"
+)
+
+//MisfirePolicy is what a job does about the times it missed while its Cron was stopped.
+type MisfirePolicy string
+
+const (
+	//MisfireSkip skips missed times. It is the default.
+	MisfireSkip MisfirePolicy = "skip"
+
+	//MisfireRunOnce releases a single Event for the first missed time when the Cron is
+	//started again.
+	MisfireRunOnce MisfirePolicy = "run-once"
+)
+
+//OverlapPolicy is what the handler of a job's Events should do when an Event is released
+//while the run for the previous one has not finished. A Cron does not run jobs, so it only
+//carries the policy for handlers.
+type OverlapPolicy string
+
+const (
+	//OverlapAllow runs the job concurrently with itself. It is the default.
+	OverlapAllow OverlapPolicy = "allow"
+
+	//OverlapSkip skips the new run.
+	OverlapSkip OverlapPolicy = "skip"
+
+	//OverlapQueue starts the new run after the previous one finishes.
+	OverlapQueue OverlapPolicy = "queue"
+)
+
+//Duration is a time.Duration that is encoded as a string such as "1m30s".
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	result, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(result)
+	return nil
+}
+
+//JobSpec declares a job, such as in a JSON, YAML, or TOML configuration file, that Apply
+//keeps in a Cron.
+type JobSpec struct {
+	//ID identifies the job and is required.
+	ID string `json:"id" yaml:"id" toml:"id"`
+
+	//Schedule is the sched expression of the job. It is parsed with ID as its seed, so that
+	//jobs with the same Schedule spread out their R fields and splay.
+	Schedule string `json:"schedule" yaml:"schedule" toml:"schedule"`
+
+	//Timezone is the name of the time zone that Schedule is in, such as "America/New_York".
+	//If empty, Schedule is in the location of the Cron.
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty" toml:"timezone,omitempty"`
+
+	//Jitter delays every time of Schedule by a fixed amount up to Jitter that is derived from
+	//ID, as with a sched.SplaySchedule.
+	Jitter Duration `json:"jitter,omitempty" yaml:"jitter,omitempty" toml:"jitter,omitempty"`
+
+	Misfire MisfirePolicy `json:"misfire,omitempty" yaml:"misfire,omitempty" toml:"misfire,omitempty"`
+	Overlap OverlapPolicy `json:"overlap,omitempty" yaml:"overlap,omitempty" toml:"overlap,omitempty"`
+
+	//Timeout is how long the handler of the job's Events should let each run take. Zero is
+	//no timeout. Like Overlap, it is only carried for handlers.
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+
+	//Enabled is whether the job is in the Cron. If nil, the job is enabled.
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty" toml:"enabled,omitempty"`
+
+	Data interface{} `json:"data,omitempty" yaml:"data,omitempty" toml:"data,omitempty"`
+}
+
+//IsEnabled returns whether the job of s is in the Cron.
+func (s *JobSpec) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+//Validate returns an error for the first problem with s.
+func (s *JobSpec) Validate() error {
+	_, err := s.NewSchedule()
+	return err
+}
+
+//NewSchedule returns the schedule of s in its Timezone and with its Jitter.
+func (s *JobSpec) NewSchedule() (sched.Schedule, error) {
+	if s.ID == "" {
+		return nil, fmt.Errorf("job does not have an id")
+	}
+	return s.newSchedule()
+}
+
+func (s *JobSpec) newSchedule() (sched.Schedule, error) {
+	if s.Schedule == "" {
+		return nil, fmt.Errorf("job %q does not have a schedule", s.ID)
+	}
+	switch s.Misfire {
+	case "", MisfireSkip, MisfireRunOnce:
+	default:
+		return nil, fmt.Errorf("job %q: unknown misfire policy %q", s.ID, s.Misfire)
+	}
+	switch s.Overlap {
+	case "", OverlapAllow, OverlapSkip, OverlapQueue:
+	default:
+		return nil, fmt.Errorf("job %q: unknown overlap policy %q", s.ID, s.Overlap)
+	}
+	if s.Jitter < 0 || s.Timeout < 0 {
+		return nil, fmt.Errorf("job %q: jitter and timeout must not be negative", s.ID)
+	}
+
+	result, err := sched.ParseWithSeed(s.Schedule, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("job %q: %w", s.ID, err)
+	}
+	return s.Wrap(result)
+}
+
+//Wrap returns schedule, which is parsed from the Schedule of s, in the Timezone of s and with
+//its Jitter, as NewSchedule does. It is for Schedules that are parsed another way, such as
+//with a sched.Parser with a Source.
+func (s *JobSpec) Wrap(schedule sched.Schedule) (sched.Schedule, error) {
+	result := schedule
+	if s.Timezone != "" {
+		loc, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: %v", s.ID, err)
+		}
+		result = sched.NewLocationSchedule(result, loc)
+	}
+	if s.Jitter > 0 {
+		result = sched.NewSplaySchedule(result, s.ID, time.Duration(s.Jitter))
+	}
+	return result, nil
+}
+
+//sameSchedule returns whether s and other have the same schedule.
+func (s *JobSpec) sameSchedule(other *JobSpec) bool {
+	return s.Schedule == other.Schedule && s.Timezone == other.Timezone && s.Jitter == other.Jitter
+}
+
+//sameJob returns whether s and other differ only in their schedules.
+func (s *JobSpec) sameJob(other *JobSpec) bool {
+	return s.Misfire == other.Misfire && s.Overlap == other.Overlap && s.Timeout == other.Timeout &&
+		reflect.DeepEqual(s.Data, other.Data)
+}
+
+//SpecError is the error for invalid JobSpecs. Apply makes no changes when there is one.
+type SpecError struct {
+	//Errs are the problems with the JobSpecs, in the order they are given.
+	Errs []error
+}
+
+func (e *SpecError) Error() string {
+	messages := make([]string, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		messages = append(messages, err.Error())
+	}
+	return "cron: invalid job specs: " + strings.Join(messages, "; ")
+}
+
+//Unwrap returns the Errs of e so that errors.As can find a *sched.ParseError in them.
+func (e *SpecError) Unwrap() []error {
+	return e.Errs
+}
+
+//ApplyResult is the changes that Apply made to a Cron, by job ID.
+type ApplyResult struct {
+	Added   []string
+	Removed []string
+
+	//Changed are the jobs whose schedules changed, and Replaced are the jobs whose other
+	//fields changed and so were removed and added again as new Jobs.
+	Changed  []string
+	Replaced []string
+}
+
+//IsEmpty returns whether r has no changes.
+func (r *ApplyResult) IsEmpty() bool {
+	return len(r.Added)+len(r.Removed)+len(r.Changed)+len(r.Replaced) == 0
+}
+
+//Apply makes the jobs of c with IDs match specs, so that c can be configured from a file.
+//Jobs are added for new specs and removed for disabled specs and IDs that are not in specs.
+//Jobs whose specs only changed their schedules keep their Jobs, and all other changed jobs
+//are replaced by new Jobs. Jobs without an ID are never changed.
+//If any spec is invalid or IDs are repeated, c is not changed and the error is a *SpecError.
+func (c *Cron) Apply(specs []*JobSpec) (*ApplyResult, error) {
+	schedules := make([]sched.Schedule, len(specs))
+	errs := []error{}
+	ids := map[string]bool{}
+	for i, spec := range specs {
+		if spec.ID == "" {
+			errs = append(errs, fmt.Errorf("job %v does not have an id", i+1))
+			continue
+		}
+		if ids[spec.ID] {
+			errs = append(errs, fmt.Errorf("job %q is declared more than once", spec.ID))
+			continue
+		}
+		ids[spec.ID] = true
+		s, err := spec.newSchedule()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		schedules[i] = s
+	}
+	if len(errs) > 0 {
+		return nil, &SpecError{errs}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	existing := map[string]*Job{}
+	undeclared := []*Job{}
+	for job := range c.jobs {
+		if job.ID == "" {
+			continue
+		}
+		//only one job of each ID is kept, preferring one from a spec.
+		other, ok := existing[job.ID]
+		if ok && (other.Spec != nil || job.Spec == nil) {
+			undeclared = append(undeclared, job)
+			continue
+		}
+		if ok {
+			undeclared = append(undeclared, other)
+		}
+		existing[job.ID] = job
+	}
+
+	result := &ApplyResult{}
+	for i, spec := range specs {
+		if !spec.IsEnabled() {
+			continue
+		}
+		job := &Job{Schedule: schedules[i], Data: spec.Data, ID: spec.ID, Spec: spec}
+		old, ok := existing[spec.ID]
+		delete(existing, spec.ID)
+		switch {
+		case !ok:
+			c.addJob(job, now)
+			result.Added = append(result.Added, spec.ID)
+		case old.Spec == nil || !old.Spec.sameJob(spec):
+			c.removeJob(old)
+			c.addJob(job, now)
+			result.Replaced = append(result.Replaced, spec.ID)
+		case !old.Spec.sameSchedule(spec):
+			c.setJobSchedule(old, schedules[i], now)
+			old.Spec = spec
+			result.Changed = append(result.Changed, spec.ID)
+		default:
+			old.Spec = spec
+		}
+	}
+	for _, job := range existing {
+		undeclared = append(undeclared, job)
+	}
+	for _, job := range undeclared {
+		c.removeJob(job)
+		result.Removed = append(result.Removed, job.ID)
+	}
+	sort.Strings(result.Removed)
+	return result, nil
+}
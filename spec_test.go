@@ -0,0 +1,71 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogolfing/cron/sched"
+)
+
+func TestJobSpec_NewSchedule_seed(t *testing.T) {
+	tests := []struct {
+		schedule string
+		jitter   Duration
+	}{
+		{"H * * * *", 0},
+		{"@hourly ~10m", 0},
+		{"@hourly", Duration(10 * time.Minute)},
+	}
+	for _, test := range tests {
+		times := map[string]string{}
+		for _, id := range []string{"a", "b", "a"} {
+			spec := &JobSpec{ID: id, Schedule: test.schedule, Jitter: test.jitter}
+			s, err := spec.NewSchedule()
+			if err != nil {
+				t.Fatalf("JobSpec{%v, %v}.NewSchedule() error = %v", id, test.schedule, err)
+			}
+			next, _ := s.NextTime(testStart)
+			result := next.String()
+			if want, ok := times[id]; ok && result != want {
+				t.Errorf("JobSpec{%v, %v}.NewSchedule() = %v WANT the same time %v", id, test.schedule, result, want)
+			}
+			times[id] = result
+		}
+		if times["a"] == times["b"] {
+			t.Errorf("JobSpec{a and b, %v}.NewSchedule() = %v for both WANT different times", test.schedule, times["a"])
+		}
+	}
+}
+
+func TestJobSpec_NewSchedule_error(t *testing.T) {
+	spec := &JobSpec{ID: "a", Schedule: "0 70 * * * *"}
+	_, err := spec.NewSchedule()
+	var pe *sched.ParseError
+	if !errors.As(err, &pe) || pe.FieldName != "minute" {
+		t.Errorf("JobSpec{%v}.NewSchedule() error = %v WANT a minute field *sched.ParseError", spec.Schedule, err)
+	}
+}
+
+func TestJobSpec_Wrap(t *testing.T) {
+	daily := sched.MustParse("0 0 0 * * *")
+	tests := []struct {
+		spec *JobSpec
+		next time.Time
+	}{
+		{&JobSpec{ID: "a"}, time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{&JobSpec{ID: "a", Timezone: "America/New_York"}, time.Date(2026, time.March, 5, 5, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		s, err := test.spec.Wrap(daily)
+		if err != nil {
+			t.Fatalf("JobSpec{%v}.Wrap() error = %v", test.spec.Timezone, err)
+		}
+		if next, _ := s.NextTime(testStart); !next.Equal(test.next) {
+			t.Errorf("JobSpec{%v}.Wrap().NextTime() = %v WANT %v", test.spec.Timezone, next, test.next)
+		}
+	}
+	if _, err := (&JobSpec{ID: "a", Timezone: "Nowhere/Nothing"}).Wrap(daily); err == nil {
+		t.Errorf("JobSpec{Nowhere/Nothing}.Wrap() error = nil WANT an error")
+	}
+}